@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kyleking/gh-lazydispatch/internal/lint"
+)
+
+// Exit codes for the lint subcommand.
+const (
+	lintExitClean  = 0
+	lintExitIssues = 1
+	lintExitUsage  = 2
+)
+
+// runLint implements `gh lazydispatch lint [dir]` and returns the process exit code.
+func runLint(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	strict := fs.Bool("strict", false, "Treat warnings as errors")
+
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gh-lazydispatch lint [--strict] [repo-dir]")
+		fmt.Fprintln(stderr, "\nChecks workflow_dispatch inputs and lazydispatch:validate comments")
+		fmt.Fprintln(stderr, "in .github/workflows and exits non-zero if errors are found.")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return lintExitUsage
+	}
+
+	repoRoot := fs.Arg(0)
+	if repoRoot == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(stderr, "Error getting current directory: %v\n", err)
+			return lintExitUsage
+		}
+
+		repoRoot = cwd
+	}
+
+	result, err := lint.Run(repoRoot)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return lintExitUsage
+	}
+
+	for _, issue := range result.Issues {
+		fmt.Fprintln(stdout, issue.String())
+	}
+
+	fmt.Fprintf(stdout, "%d workflow file(s) checked, %d issue(s)\n", result.Files, len(result.Issues))
+
+	if result.HasErrors() || (*strict && len(result.Issues) > 0) {
+		return lintExitIssues
+	}
+
+	return lintExitClean
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunLint_ExitCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		workflow string
+		args     []string
+		want     int
+	}{
+		{
+			name:     "clean",
+			workflow: "on:\n  workflow_dispatch:\n    inputs:\n      debug:\n        type: boolean\n        default: false\n",
+			want:     lintExitClean,
+		},
+		{
+			name:     "required input without default is an error",
+			workflow: "on:\n  workflow_dispatch:\n    inputs:\n      version:\n        required: true\n",
+			want:     lintExitIssues,
+		},
+		{
+			name:     "warnings pass",
+			workflow: "on:\n  workflow_dispatch:\n    inputs:\n      debug:\n        # lazydispatch:options:git-tags\n        type: boolean\n",
+			want:     lintExitClean,
+		},
+		{
+			name:     "warnings fail under strict",
+			workflow: "on:\n  workflow_dispatch:\n    inputs:\n      debug:\n        # lazydispatch:options:git-tags\n        type: boolean\n",
+			args:     []string{"--strict"},
+			want:     lintExitIssues,
+		},
+		{
+			name: "unknown flag",
+			args: []string{"--bogus"},
+			want: lintExitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			dir := filepath.Join(root, ".github", "workflows")
+
+			if err := os.MkdirAll(dir, 0o750); err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.workflow != "" {
+				if err := os.WriteFile(filepath.Join(dir, "wf.yml"), []byte(tt.workflow), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var stdout, stderr strings.Builder
+
+			if got := runLint(append(tt.args, root), &stdout, &stderr); got != tt.want {
+				t.Errorf("runLint() = %d, want %d\nstdout:\n%s\nstderr:\n%s", got, tt.want, stdout.String(), stderr.String())
+			}
+		})
+	}
+}
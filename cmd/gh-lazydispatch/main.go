@@ -4,7 +4,9 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 
 	tea "charm.land/bubbletea/v2"
 
@@ -26,12 +28,17 @@ func main() {
 	var (
 		showVersion bool
 		showHelp    bool
+		remoteRepo  string
+		remoteRef   string
 	)
 
 	flag.BoolVar(&showVersion, "version", false, "Show version")
 	flag.BoolVar(&showVersion, "v", false, "Show version (shorthand)")
 	flag.BoolVar(&showHelp, "help", false, "Show help")
 	flag.BoolVar(&showHelp, "h", false, "Show help (shorthand)")
+	flag.StringVar(&remoteRepo, "repo", "", "Dispatch in owner/repo without a local checkout")
+	flag.StringVar(&remoteRepo, "R", "", "Dispatch in owner/repo without a local checkout (shorthand)")
+	flag.StringVar(&remoteRef, "ref", "", "Branch to read workflows from with --repo (default: the default branch)")
 	flag.Parse()
 
 	if showVersion {
@@ -44,29 +51,46 @@ func main() {
 		os.Exit(0)
 	}
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-		os.Exit(1)
+	if flag.Arg(0) == "lint" {
+		os.Exit(runLint(flag.Args()[1:], os.Stdout, os.Stderr))
 	}
 
-	workflows, err := workflow.Discover(cwd)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error discovering workflows: %v\n", err)
-		os.Exit(1)
+	if flag.Arg(0) == "run" {
+		os.Exit(runRun(flag.Args()[1:], os.Stdout, os.Stderr))
 	}
 
-	if len(workflows) == 0 {
-		fmt.Println("No dispatchable workflows found in .github/workflows/")
-		fmt.Println("\nWorkflows must have 'workflow_dispatch' trigger to be dispatchable.")
-		os.Exit(0)
+	var (
+		report *workflow.DiscoveryReport
+		remote *remoteTarget
+		repo   string
+	)
+
+	if remoteRepo != "" {
+		var err error
+
+		remote, err = discoverRemote(remoteRepo, remoteRef)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error discovering workflows: %v\n", err)
+			os.Exit(1)
+		}
+
+		report, repo = remote.report, remoteRepo
+	} else {
+		report = discoverLocal()
 	}
 
-	repo, err := runner.DetectRepo()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not detect repository: %v\n", err)
+	if len(report.Dispatchable) == 0 {
+		printDiscoveryReport(os.Stdout, report)
+
+		if report.HasFailures() {
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
 
-		repo = "unknown/unknown"
+	if remote == nil {
+		repo = detectLocalRepo()
 	}
 
 	history, err := frecency.Load()
@@ -79,7 +103,17 @@ func main() {
 	detectedTheme := theme.Detect()
 	ui.InitTheme(detectedTheme)
 
-	model := app.New(workflows, history, repo)
+	var model app.Model
+	if remote != nil {
+		model = app.NewRemote(report.Dispatchable, history, repo, remote.branch, remote.config)
+	} else {
+		model = app.New(report.Dispatchable, history, repo)
+		if cwd, err := os.Getwd(); err == nil {
+			model = model.WithReload(cwd)
+		}
+	}
+
+	model = model.WithDiscoveryReport(report)
 
 	p := tea.NewProgram(model)
 	if _, err := p.Run(); err != nil {
@@ -88,18 +122,102 @@ func main() {
 	}
 }
 
+// discoverLocal reads workflows from the working directory's checkout, exiting on failure.
+func discoverLocal() *workflow.DiscoveryReport {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	report, err := discoverLocalReport(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error discovering workflows: %v\n", err)
+		os.Exit(1)
+	}
+
+	return report
+}
+
+// discoverLocalReport reads the workflows of the checkout at root through the
+// workflow cache.
+func discoverLocalReport(root string) (*workflow.DiscoveryReport, error) {
+	// Without a user cache directory, every start parses every workflow.
+	var cache *workflow.Cache
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		cache = workflow.NewCache(filepath.Join(cacheDir, "lazydispatch", "workflows"), root)
+	}
+
+	report, err := workflow.DiscoverCached(root, cache)
+	if err != nil {
+		return nil, fmt.Errorf("discovering workflows: %w", err)
+	}
+
+	return report, nil
+}
+
+// detectLocalRepo returns the working directory's repository in "owner/repo" format.
+func detectLocalRepo() string {
+	repo, err := runner.DetectRepo()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not detect repository: %v\n", err)
+
+		return "unknown/unknown"
+	}
+
+	return repo
+}
+
+// printDiscoveryReport explains why no dispatchable workflows were found,
+// listing non-dispatchable workflows and every file that failed to parse.
+func printDiscoveryReport(w io.Writer, report *workflow.DiscoveryReport) {
+	fmt.Fprintln(w, "No dispatchable workflows found in .github/workflows/")
+	fmt.Fprintln(w, "\nWorkflows must have 'workflow_dispatch' trigger to be dispatchable.")
+
+	if len(report.NonDispatchable) > 0 {
+		fmt.Fprintf(w, "\nWorkflows without workflow_dispatch (%d):\n", len(report.NonDispatchable))
+
+		for _, wf := range report.NonDispatchable {
+			fmt.Fprintf(w, "  %s\n", wf.Filename)
+		}
+	}
+
+	if report.HasFailures() {
+		fmt.Fprintf(w, "\nFiles that failed to parse (%d):\n", len(report.Failures))
+
+		for _, failure := range report.Failures {
+			fmt.Fprintf(w, "  %s\n", failure.Error())
+		}
+	}
+}
+
 func printHelp() {
 	fmt.Println(`gh-lazydispatch - Interactive GitHub Workflow Dispatcher
 
 Usage:
   gh-lazydispatch [flags]
+  gh-lazydispatch --repo owner/repo [--ref branch]
+  gh-lazydispatch lint [--strict] [repo-dir]
+  gh-lazydispatch run <workflow> [--ref branch] [-f key=value ...]
+                      [--preset name] [--wait] [--json]
 
 Description:
   A TUI for triggering GitHub Actions workflow_dispatch workflows with
   fuzzy selection, interactive input configuration, and frecency-based
   history tracking.
 
+Commands:
+  lint           Check workflow_dispatch inputs and validation comments,
+                 exiting non-zero on errors (for pre-commit and CI)
+  run            Dispatch a workflow without the TUI, exiting 0 on success,
+                 1 when a run waited for with --wait fails, and 2 for
+                 invalid inputs or a failed dispatch
+
 Flags:
+  -R, --repo     Dispatch in owner/repo through the GitHub API, without a
+                 local checkout
+  --ref          Branch to read workflows from with --repo (default: the
+                 repository's default branch)
   -h, --help     Show this help message
   -v, --version  Show version (includes commit and build date)
 
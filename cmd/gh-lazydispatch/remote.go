@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// remoteTarget is a repository discovered through the GitHub API for --repo.
+type remoteTarget struct {
+	report *workflow.DiscoveryReport
+	config *config.WfdConfig
+	branch string
+}
+
+// discoverRemote reads the workflows and lazydispatch.yml of repo at ref, or at
+// the default branch if ref is empty, without a local checkout.
+func discoverRemote(repo, ref string) (*remoteTarget, error) {
+	client, err := github.NewClient(repo)
+	if err != nil {
+		return nil, fmt.Errorf("creating client: %w", err)
+	}
+
+	branch := ref
+	if branch == "" {
+		branch, err = client.GetDefaultBranch()
+		if err != nil {
+			return nil, fmt.Errorf("resolving default branch of %s: %w", repo, err)
+		}
+	}
+
+	report, err := workflow.DiscoverFrom(github.NewContentsSource(client, branch))
+	if err != nil {
+		return nil, fmt.Errorf("reading workflows of %s at %s: %w", repo, branch, err)
+	}
+
+	target := &remoteTarget{report: report, branch: branch}
+
+	// A missing or unreadable lazydispatch.yml just means no chains. Values
+	// files that cannot be read are recorded on their rules.
+	if data, err := client.GetFileContent(config.ConfigFilename, branch); err == nil {
+		cfg, err := config.Parse(data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not load %s: %v\n", config.ConfigFilename, err)
+		} else {
+			if err := cfg.LoadAllowedValues(func(path string) ([]byte, error) {
+				return client.GetFileContent(path, branch)
+			}); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+
+			target.config = cfg
+		}
+	}
+
+	return target, nil
+}
@@ -0,0 +1,482 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/frecency"
+	"github.com/kyleking/gh-lazydispatch/internal/git"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
+	"github.com/kyleking/gh-lazydispatch/internal/runner"
+	"github.com/kyleking/gh-lazydispatch/internal/validation"
+	"github.com/kyleking/gh-lazydispatch/internal/watcher"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// Exit codes for the run subcommand.
+const (
+	runExitSuccess = 0
+	runExitFailed  = 1 // the run finished without succeeding
+	runExitError   = 2 // bad arguments, invalid inputs, or a failed dispatch
+)
+
+// Errors returned while preparing a headless dispatch.
+var (
+	errRunUsage        = errors.New("expected exactly one workflow")
+	errInvalidField    = errors.New("expected key=value")
+	errUnknownWorkflow = errors.New("no dispatchable workflow")
+	errUnknownPreset   = errors.New("no preset")
+	errLockedInput     = errors.New("input is locked in " + config.ConfigFilename)
+	errNoBranch        = errors.New("could not determine the current branch; pass --ref")
+)
+
+// runClient is the GitHub API the run subcommand dispatches and watches through.
+type runClient interface {
+	runner.GitHubClient
+	watcher.GitHubClient
+}
+
+// runOptions holds the parsed arguments of the run subcommand.
+type runOptions struct {
+	inputs   map[string]string
+	workflow string
+	ref      string
+	preset   string
+	repo     string
+	wait     bool
+	json     bool
+}
+
+// runTarget is where the run subcommand dispatches: the repository, its
+// workflows at the branch to dispatch on, and its lazydispatch.yml.
+type runTarget struct {
+	client    runClient
+	config    *config.WfdConfig
+	repo      string
+	branch    string
+	workflows []workflow.File
+}
+
+// runResult is the outcome of the run subcommand, printed with --json.
+// Status and Conclusion are only set with --wait.
+type runResult struct {
+	Workflow   string  `json:"workflow"`
+	Ref        string  `json:"ref"`
+	URL        string  `json:"url,omitempty"`
+	Status     string  `json:"status,omitempty"`
+	Conclusion string  `json:"conclusion,omitempty"`
+	Error      string  `json:"error,omitempty"`
+	RunID      int64   `json:"run_id,omitempty"`
+	Duration   float64 `json:"duration_seconds"`
+}
+
+// runRun implements `gh lazydispatch run <workflow>` and returns the process exit code.
+func runRun(args []string, stdout, stderr io.Writer) int {
+	opts, err := parseRunArgs(args, stderr)
+	if err != nil {
+		return runExitError
+	}
+
+	target, err := loadRunTarget(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return runExitError
+	}
+
+	// A preset that pins a branch dispatches there unless --ref says otherwise.
+	if wf, ok := findWorkflow(target.workflows, opts.workflow); ok && opts.ref == "" {
+		if preset, ok := target.config.Presets(wf.Filename)[opts.preset]; ok && preset.Branch != "" &&
+			preset.Branch != target.branch {
+			opts.ref = preset.Branch
+
+			if target, err = loadRunTarget(opts); err != nil {
+				fmt.Fprintf(stderr, "Error: %v\n", err)
+				return runExitError
+			}
+		}
+	}
+
+	history, err := frecency.Load()
+	if err != nil {
+		fmt.Fprintf(stderr, "Warning: could not load history: %v\n", err)
+
+		history = frecency.NewStore()
+	}
+
+	code := executeRun(opts, target, history, stdout, stderr)
+
+	if err := history.Save(); err != nil {
+		fmt.Fprintf(stderr, "Warning: could not save history: %v\n", err)
+	}
+
+	return code
+}
+
+// parseRunArgs parses the run subcommand's arguments, printing usage to
+// stderr when they are invalid. Flags may come before or after the workflow.
+func parseRunArgs(args []string, stderr io.Writer) (runOptions, error) {
+	opts := runOptions{inputs: make(map[string]string)}
+
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.StringVar(&opts.ref, "ref", "", "Branch to dispatch on (default: the current branch, or the default branch with --repo)")
+	fs.StringVar(&opts.preset, "preset", "", "Start from a preset in "+config.ConfigFilename)
+	fs.StringVar(&opts.repo, "repo", "", "Dispatch in owner/repo without a local checkout")
+	fs.StringVar(&opts.repo, "R", "", "Dispatch in owner/repo without a local checkout (shorthand)")
+	fs.BoolVar(&opts.wait, "wait", false, "Wait for the run to finish and exit 1 unless it succeeds")
+	fs.BoolVar(&opts.json, "json", false, "Print the result as JSON")
+	fs.Func("f", "Set an input as `key=value` (repeatable)", func(field string) error {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("%w: %q", errInvalidField, field)
+		}
+
+		opts.inputs[name] = value
+
+		return nil
+	})
+
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gh-lazydispatch run <workflow> [--ref branch] [-f key=value ...] [--preset name] [--wait] [--json]")
+		fmt.Fprintln(stderr, "\nDispatches a workflow without the TUI, checking its inputs first. Exits 0 on")
+		fmt.Fprintln(stderr, "success, 1 when a run waited for with --wait fails, and 2 for invalid")
+		fmt.Fprintln(stderr, "arguments or inputs and failed dispatches.")
+		fs.PrintDefaults()
+	}
+
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return runOptions{}, fmt.Errorf("parsing run arguments: %w", err)
+		}
+
+		if fs.NArg() == 0 {
+			break
+		}
+
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != 1 {
+		fs.Usage()
+		return runOptions{}, errRunUsage
+	}
+
+	opts.workflow = positional[0]
+
+	return opts, nil
+}
+
+// loadRunTarget finds the repository and workflows opts dispatches in: the
+// checkout in the working directory, with the workflows read from opts.ref
+// when it is not the current branch, or opts.repo through the API.
+func loadRunTarget(opts runOptions) (runTarget, error) {
+	if opts.repo != "" {
+		remote, err := discoverRemote(opts.repo, opts.ref)
+		if err != nil {
+			return runTarget{}, err
+		}
+
+		client, err := github.NewClient(opts.repo)
+		if err != nil {
+			return runTarget{}, fmt.Errorf("creating client: %w", err)
+		}
+
+		return runTarget{
+			client:    client,
+			config:    remote.config,
+			repo:      opts.repo,
+			branch:    remote.branch,
+			workflows: remote.config.ApplyOverrides(remote.report.Dispatchable),
+		}, nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return runTarget{}, fmt.Errorf("getting current directory: %w", err)
+	}
+
+	ctx := context.Background()
+
+	current := git.GetCurrentBranch(ctx)
+
+	branch := opts.ref
+	if branch == "" {
+		branch = current
+	}
+
+	if branch == "" {
+		return runTarget{}, errNoBranch
+	}
+
+	var report *workflow.DiscoveryReport
+
+	if branch == current {
+		report, err = discoverLocalReport(cwd)
+	} else {
+		var src *git.RefSource
+		if src, err = git.NewRefSource(ctx, branch); err == nil {
+			report, err = workflow.DiscoverFrom(src)
+		}
+	}
+
+	if err != nil {
+		return runTarget{}, fmt.Errorf("discovering workflows on %s: %w", branch, err)
+	}
+
+	cfg, err := config.Load(cwd)
+	if err != nil && !errors.Is(err, config.ErrConfigNotFound) {
+		return runTarget{}, fmt.Errorf("loading %s: %w", config.ConfigFilename, err)
+	}
+
+	repo, err := runner.DetectRepo()
+	if err != nil {
+		return runTarget{}, fmt.Errorf("detecting repository: %w", err)
+	}
+
+	client, err := github.NewClient(repo)
+	if err != nil {
+		return runTarget{}, fmt.Errorf("creating client: %w", err)
+	}
+
+	return runTarget{
+		client:    client,
+		config:    cfg,
+		repo:      repo,
+		branch:    branch,
+		workflows: cfg.ApplyOverrides(report.Dispatchable),
+	}, nil
+}
+
+// executeRun checks opts's inputs against the workflow, records and
+// dispatches it, and with opts.wait watches the run until it finishes.
+func executeRun(opts runOptions, target runTarget, history *frecency.Store, stdout, stderr io.Writer) int {
+	start := time.Now()
+	result := runResult{Workflow: opts.workflow, Ref: target.branch}
+
+	fail := func(code int, err error) int {
+		result.Error = err.Error()
+		result.Duration = time.Since(start).Seconds()
+
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+
+		if opts.json {
+			writeRunResult(stdout, stderr, result)
+		}
+
+		return code
+	}
+
+	wf, ok := findWorkflow(target.workflows, opts.workflow)
+	if !ok {
+		return fail(runExitError, fmt.Errorf("%w named %q on %s", errUnknownWorkflow, opts.workflow, target.branch))
+	}
+
+	result.Workflow = wf.Filename
+
+	values, err := runInputs(wf, target.config, opts)
+	if err != nil {
+		return fail(runExitError, err)
+	}
+
+	if errs := validation.ValidateInputs(values, &wf, nil); len(errs) > 0 {
+		for _, verr := range errs {
+			fmt.Fprintf(stderr, "  %s\n", describeValidationError(verr))
+		}
+
+		return fail(runExitError, fmt.Errorf("%d invalid input(s) for %s", len(errs), wf.Filename))
+	}
+
+	cfg := runner.RunConfig{
+		Workflow:   wf.Filename,
+		Branch:     target.branch,
+		Inputs:     runner.ExplicitInputs(wf, values),
+		InputTypes: make(map[string]string),
+		Repo:       target.repo,
+	}
+
+	for name, input := range wf.GetInputs() {
+		cfg.InputTypes[name] = input.InputType()
+	}
+
+	if _, err := runner.BuildPayload(cfg); err != nil {
+		return fail(runExitError, err)
+	}
+
+	history.Record(target.repo, cfg.Workflow, cfg.Branch, cfg.Inputs)
+
+	dispatched, err := runner.Dispatch(cfg, target.client)
+	if err != nil {
+		return fail(runExitError, err)
+	}
+
+	result.RunID, result.URL = dispatched.RunID, dispatched.URL
+
+	if !opts.json {
+		location := dispatched.URL
+		if location == "" {
+			location = fmt.Sprintf("run %d", dispatched.RunID)
+		}
+
+		fmt.Fprintf(stdout, "Dispatched %s on %s: %s\n", wf.Filename, target.branch, location)
+	}
+
+	code := runExitSuccess
+
+	if opts.wait {
+		run := waitForRun(target.client, dispatched.RunID, wf.Filename, stderr)
+		result.Status, result.Conclusion = run.Status, run.Conclusion
+
+		if run.HTMLURL != "" {
+			result.URL = run.HTMLURL
+		}
+
+		if !run.IsSuccess() {
+			code = runExitFailed
+		}
+	}
+
+	result.Duration = time.Since(start).Seconds()
+
+	switch {
+	case opts.json:
+		writeRunResult(stdout, stderr, result)
+	case opts.wait:
+		fmt.Fprintf(stdout, "Run %d %s: %s after %s\n", result.RunID, result.Status, result.Conclusion,
+			time.Since(start).Round(time.Second))
+	}
+
+	return code
+}
+
+// findWorkflow returns the workflow named name: its filename, with or
+// without the extension, or its name: field.
+func findWorkflow(workflows []workflow.File, name string) (workflow.File, bool) {
+	for _, wf := range workflows {
+		base := strings.TrimSuffix(strings.TrimSuffix(wf.Filename, ".yml"), ".yaml")
+		if wf.Filename == name || base == name || (wf.Name != "" && wf.Name == name) {
+			return wf, true
+		}
+	}
+
+	return workflow.File{}, false
+}
+
+// runInputs returns the values to check and send: every input's default,
+// then the preset's values, then opts.inputs. As in the TUI, a preset does
+// not change locked and hidden inputs, and setting one with -f is an error.
+func runInputs(wf workflow.File, cfg *config.WfdConfig, opts runOptions) (map[string]string, error) {
+	inputs := wf.GetInputs()
+	values := make(map[string]string, len(inputs))
+
+	for name, input := range inputs {
+		values[name] = input.Default
+	}
+
+	if opts.preset != "" {
+		presets := cfg.Presets(wf.Filename)
+
+		preset, ok := presets[opts.preset]
+		if !ok {
+			return nil, fmt.Errorf("%w named %q for %s (have: %s)", errUnknownPreset, opts.preset, wf.Filename,
+				strings.Join(slices.Sorted(maps.Keys(presets)), ", "))
+		}
+
+		for name, value := range preset.Inputs {
+			if input, declared := inputs[name]; !declared || (!input.Locked && !input.Hidden) {
+				values[name] = value
+			}
+		}
+	}
+
+	for name, value := range opts.inputs {
+		if input, declared := inputs[name]; declared && (input.Locked || input.Hidden) {
+			return nil, fmt.Errorf("%w: %s", errLockedInput, name)
+		}
+
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// describeValidationError explains why an input value cannot be dispatched.
+func describeValidationError(err validation.ConfigValidationError) string {
+	var reason string
+
+	switch err.Status {
+	case validation.StatusValid:
+		return err.HistoricalName
+	case validation.StatusMissing:
+		reason = "not an input of this workflow"
+	case validation.StatusTypeChanged:
+		reason = fmt.Sprintf("%q does not fit the input's type", err.HistoricalValue)
+	case validation.StatusOptionsChanged:
+		reason = fmt.Sprintf("%q is not one of the options", err.HistoricalValue)
+	case validation.StatusEnvironmentMissing:
+		reason = fmt.Sprintf("no environment named %q", err.HistoricalValue)
+	case validation.StatusRequiredMissing:
+		reason = "required"
+	case validation.StatusRuleFailed:
+		reason = err.Details
+	}
+
+	if err.Suggestion != "" {
+		reason += fmt.Sprintf(" (did you mean %q?)", err.Suggestion)
+	}
+
+	return err.HistoricalName + ": " + reason
+}
+
+// waitForRun watches runID until it finishes, reporting each status change
+// and polling error to stderr, and returns its final state.
+func waitForRun(client watcher.GitHubClient, runID int64, workflowName string, stderr io.Writer) watcher.WatchedRun {
+	w := watcher.NewWatcher(client)
+	defer w.Stop()
+
+	w.Watch(runID, workflowName)
+
+	var status string
+
+	for update := range w.Updates() {
+		if update.RunID != runID {
+			continue
+		}
+
+		if update.Error != nil {
+			fmt.Fprintf(stderr, "Warning: polling run %d: %v\n", runID, update.Error)
+			continue
+		}
+
+		if update.Run.Status != status {
+			status = update.Run.Status
+			fmt.Fprintf(stderr, "Run %d: %s\n", runID, status)
+		}
+
+		if !update.Run.IsActive() {
+			return update.Run
+		}
+	}
+
+	return watcher.WatchedRun{RunID: runID}
+}
+
+// writeRunResult prints result as one line of JSON.
+func writeRunResult(stdout, stderr io.Writer, result runResult) {
+	if err := json.NewEncoder(stdout).Encode(result); err != nil {
+		fmt.Fprintf(stderr, "Error: encoding result: %v\n", err)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/frecency"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+var errDispatchRejected = errors.New("HTTP 422: workflow has no workflow_dispatch trigger")
+
+// fakeRunClient dispatches run 42 and reports it finished with conclusion.
+type fakeRunClient struct {
+	dispatchErr error
+	payload     *github.DispatchPayload
+	conclusion  string
+}
+
+func (c *fakeRunClient) DispatchWorkflow(_ string, payload github.DispatchPayload) (*github.DispatchResponse, error) {
+	if c.dispatchErr != nil {
+		return nil, c.dispatchErr
+	}
+
+	c.payload = &payload
+
+	return &github.DispatchResponse{RunID: 42, HTMLURL: "https://github.com/owner/repo/actions/runs/42"}, nil
+}
+
+func (*fakeRunClient) FindDispatchedRun(string, *github.DispatchResponse) (*github.WorkflowRun, error) {
+	return nil, github.ErrNoWorkflowRuns
+}
+
+func (c *fakeRunClient) GetWorkflowRun(runID int64) (*github.WorkflowRun, error) {
+	return &github.WorkflowRun{
+		ID:         runID,
+		Name:       "Deploy",
+		Status:     github.StatusCompleted,
+		Conclusion: c.conclusion,
+		HTMLURL:    "https://github.com/owner/repo/actions/runs/42",
+	}, nil
+}
+
+func (*fakeRunClient) GetWorkflowRunJobs(int64) ([]github.Job, error) {
+	return nil, nil
+}
+
+func runTestTarget(client runClient) runTarget {
+	return runTarget{
+		client: client,
+		config: &config.WfdConfig{Workflows: map[string]config.WorkflowOverride{
+			"deploy.yml": {Presets: map[string]config.Preset{
+				"staging-canary": {Inputs: map[string]string{"env": "staging", "canary": "true"}},
+			}},
+		}},
+		repo:   "owner/repo",
+		branch: "main",
+		workflows: []workflow.File{{
+			Filename: "deploy.yml",
+			Name:     "Deploy",
+			On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+				"env":    {Type: "choice", Options: []string{"staging", "production"}, Default: "production"},
+				"canary": {Type: "boolean", Default: "false"},
+				"region": {Type: "string", Default: "us-east-1", Locked: true},
+			}}},
+		}},
+	}
+}
+
+func TestParseRunArgs(t *testing.T) {
+	t.Parallel()
+
+	var stderr strings.Builder
+
+	opts, err := parseRunArgs([]string{"--wait", "deploy", "-f", "env=staging", "-f", "note=a=b", "--json"}, &stderr)
+	if err != nil {
+		t.Fatalf("parseRunArgs failed: %v\n%s", err, stderr.String())
+	}
+
+	if opts.workflow != "deploy" || !opts.wait || !opts.json {
+		t.Errorf("opts = %+v", opts)
+	}
+
+	if want := map[string]string{"env": "staging", "note": "a=b"}; !maps.Equal(opts.inputs, want) {
+		t.Errorf("inputs = %v, want %v", opts.inputs, want)
+	}
+
+	for _, args := range [][]string{{}, {"a", "b"}, {"deploy", "-f", "env"}, {"--bogus", "deploy"}} {
+		if _, err := parseRunArgs(args, &stderr); err == nil {
+			t.Errorf("parseRunArgs(%q) succeeded, want an error", args)
+		}
+	}
+}
+
+func TestExecuteRun(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		client      *fakeRunClient
+		wantInputs  map[string]any
+		name        string
+		wantResult  runResult
+		opts        runOptions
+		wantCode    int
+		wantHistory bool
+	}{
+		{
+			name:        "preset with field override",
+			client:      &fakeRunClient{},
+			opts:        runOptions{workflow: "deploy", preset: "staging-canary", inputs: map[string]string{"env": "production"}},
+			wantCode:    runExitSuccess,
+			wantInputs:  map[string]any{"env": "production", "canary": true, "region": "us-east-1"},
+			wantResult:  runResult{Workflow: "deploy.yml", Ref: "main", RunID: 42, URL: "https://github.com/owner/repo/actions/runs/42"},
+			wantHistory: true,
+		},
+		{
+			name:        "wait for success",
+			client:      &fakeRunClient{conclusion: github.ConclusionSuccess},
+			opts:        runOptions{workflow: "Deploy", wait: true},
+			wantCode:    runExitSuccess,
+			wantInputs:  map[string]any{"env": "production", "canary": false, "region": "us-east-1"},
+			wantResult:  runResult{Workflow: "deploy.yml", Ref: "main", RunID: 42, URL: "https://github.com/owner/repo/actions/runs/42", Status: github.StatusCompleted, Conclusion: github.ConclusionSuccess},
+			wantHistory: true,
+		},
+		{
+			name:        "wait for failure",
+			client:      &fakeRunClient{conclusion: "failure"},
+			opts:        runOptions{workflow: "deploy.yml", wait: true},
+			wantCode:    runExitFailed,
+			wantInputs:  map[string]any{"env": "production", "canary": false, "region": "us-east-1"},
+			wantResult:  runResult{Workflow: "deploy.yml", Ref: "main", RunID: 42, URL: "https://github.com/owner/repo/actions/runs/42", Status: github.StatusCompleted, Conclusion: "failure"},
+			wantHistory: true,
+		},
+		{
+			name:       "unknown workflow",
+			client:     &fakeRunClient{},
+			opts:       runOptions{workflow: "release"},
+			wantCode:   runExitError,
+			wantResult: runResult{Workflow: "release", Ref: "main"},
+		},
+		{
+			name:       "unknown preset",
+			client:     &fakeRunClient{},
+			opts:       runOptions{workflow: "deploy", preset: "prod"},
+			wantCode:   runExitError,
+			wantResult: runResult{Workflow: "deploy.yml", Ref: "main"},
+		},
+		{
+			name:       "invalid choice",
+			client:     &fakeRunClient{},
+			opts:       runOptions{workflow: "deploy", inputs: map[string]string{"env": "qa"}},
+			wantCode:   runExitError,
+			wantResult: runResult{Workflow: "deploy.yml", Ref: "main"},
+		},
+		{
+			name:       "locked input",
+			client:     &fakeRunClient{},
+			opts:       runOptions{workflow: "deploy", inputs: map[string]string{"region": "eu-west-1"}},
+			wantCode:   runExitError,
+			wantResult: runResult{Workflow: "deploy.yml", Ref: "main"},
+		},
+		{
+			name:        "dispatch rejected",
+			client:      &fakeRunClient{dispatchErr: errDispatchRejected},
+			opts:        runOptions{workflow: "deploy"},
+			wantCode:    runExitError,
+			wantResult:  runResult{Workflow: "deploy.yml", Ref: "main"},
+			wantHistory: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr strings.Builder
+
+			history := frecency.NewStore()
+			tt.opts.json = true
+
+			if got := executeRun(tt.opts, runTestTarget(tt.client), history, &stdout, &stderr); got != tt.wantCode {
+				t.Errorf("executeRun() = %d, want %d\nstderr:\n%s", got, tt.wantCode, stderr.String())
+			}
+
+			var result runResult
+			if err := json.Unmarshal([]byte(stdout.String()), &result); err != nil {
+				t.Fatalf("stdout is not one JSON result: %v\n%s", err, stdout.String())
+			}
+
+			if (result.Error != "") != (tt.wantCode == runExitError) {
+				t.Errorf("error = %q with exit code %d", result.Error, tt.wantCode)
+			}
+
+			result.Error, result.Duration = "", 0
+			if result != tt.wantResult {
+				t.Errorf("result = %+v, want %+v", result, tt.wantResult)
+			}
+
+			if tt.wantInputs != nil {
+				if tt.client.payload == nil {
+					t.Fatal("expected a dispatch")
+				}
+
+				if !maps.Equal(tt.client.payload.Inputs, tt.wantInputs) || tt.client.payload.Ref != "main" {
+					t.Errorf("payload = %+v, want inputs %v on main", *tt.client.payload, tt.wantInputs)
+				}
+			} else if tt.client.payload != nil {
+				t.Errorf("expected no dispatch, sent %+v", *tt.client.payload)
+			}
+
+			if recorded := len(history.TopForRepo("owner/repo", "deploy.yml", 1)) > 0; recorded != tt.wantHistory {
+				t.Errorf("recorded in history = %v, want %v", recorded, tt.wantHistory)
+			}
+		})
+	}
+}
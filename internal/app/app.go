@@ -14,6 +14,8 @@ import (
 	"github.com/kyleking/gh-lazydispatch/internal/git"
 	"github.com/kyleking/gh-lazydispatch/internal/github"
 	"github.com/kyleking/gh-lazydispatch/internal/logs"
+	"github.com/kyleking/gh-lazydispatch/internal/options"
+	"github.com/kyleking/gh-lazydispatch/internal/reload"
 	"github.com/kyleking/gh-lazydispatch/internal/ui/modal"
 	"github.com/kyleking/gh-lazydispatch/internal/ui/panes"
 	"github.com/kyleking/gh-lazydispatch/internal/watcher"
@@ -60,9 +62,19 @@ type Model struct {
 	logStreamer             *logs.LogStreamer
 	modalStack              *modal.Stack
 	ghClient                *github.Client
+	optionsLoader           *options.Loader
 	logManager              *logs.Manager
 	previewingHistoryEntry  *frecency.HistoryEntry
+	pendingReplay           *frecency.HistoryEntry
+	environmentsPicker      *environmentsPicker
+	pendingPreset           *config.Preset
+	discoveryReport         *workflow.DiscoveryReport
+	fileWatcher             *reload.Watcher
+	inputChanges            map[string]workflow.InputChange
 	repo                    string
+	workflowsRef            string
+	staleRef                string
+	reloadNotice            string
 	executingChainName      string
 	executingChainBranch    string
 	branch                  string
@@ -71,9 +83,12 @@ type Model struct {
 	filterText              string
 	keys                    KeyMap
 	inputOrder              []string
+	environments            []github.Environment
 	filteredInputs          []string
 	pendingChainCommands    []string
 	workflows               []workflow.File
+	baseWorkflows           []workflow.File
+	localWorkflows          []workflow.File
 	rightPanel              panes.TabbedRightModel
 	height                  int
 	viewMode                ViewMode
@@ -81,7 +96,13 @@ type Model struct {
 	selectedWorkflow        int
 	width                   int
 	selectedInput           int
+	reloadNoticeSeq         int
 	watchRun                bool
+	runUpdatesSubscribed    bool
+	pendingPresetSave       bool
+	environmentsLoaded      bool
+	environmentsLoading     bool
+	remote                  bool
 }
 
 // RunUpdateMsg is sent when a watched run is updated.
@@ -94,14 +115,48 @@ type ChainUpdateMsg struct {
 	Update chain.ChainUpdate
 }
 
-// New creates a new application model.
+// BranchWorkflowsMsg carries the workflows discovered at a branch chosen in the branch modal.
+// A non-nil Err means the branch could not be read and the local workflows apply.
+type BranchWorkflowsMsg struct {
+	Err    error
+	Report *workflow.DiscoveryReport
+	Branch string
+	// StaleRef names the git ref the workflows were read from when it may lag
+	// behind GitHub: a remote-tracking ref as of the last fetch, or a local
+	// branch. Empty when they were read through the API.
+	StaleRef string
+}
+
+// New creates a new application model for the checkout in the working
+// directory, starting on its current branch with its lazydispatch.yml.
 func New(workflows []workflow.File, history *frecency.Store, repo string) Model {
-	ctx := context.Background()
-	currentBranch := git.GetCurrentBranch(ctx)
+	var cfg *config.WfdConfig
+	if loaded, err := config.Load("."); err == nil {
+		cfg = loaded
+	}
 
+	return newModel(workflows, history, repo, git.GetCurrentBranch(context.Background()), cfg, false)
+}
+
+// NewRemote creates a model for a repository with no local checkout:
+// dispatches name the repository explicitly, branches, tags and workflow
+// files come from the API, cmd option providers do not run, and cfg (nil if
+// the repository has none) is its lazydispatch.yml. Nothing is read from the
+// working directory.
+func NewRemote(
+	workflows []workflow.File, history *frecency.Store, repo, branch string, cfg *config.WfdConfig,
+) Model {
+	return newModel(workflows, history, repo, branch, cfg, true)
+}
+
+func newModel(
+	workflows []workflow.File, history *frecency.Store, repo, currentBranch string, cfg *config.WfdConfig, remote bool,
+) Model {
 	m := Model{
 		focused:          PaneWorkflows,
 		workflows:        workflows,
+		baseWorkflows:    workflows,
+		localWorkflows:   workflows,
 		history:          history,
 		repo:             repo,
 		branch:           currentBranch,
@@ -111,6 +166,7 @@ func New(workflows []workflow.File, history *frecency.Store, repo string) Model
 		selectedInput:    -1,
 		selectedWorkflow: -1,
 		rightPanel:       panes.NewTabbedRight(),
+		remote:           remote,
 	}
 
 	if ghClient, err := github.NewClient(repo); err == nil {
@@ -130,28 +186,67 @@ func New(workflows []workflow.File, history *frecency.Store, repo string) Model
 		m.logManager.LoadCache()
 	}
 
-	if cfg, err := config.Load("."); err == nil && cfg != nil {
+	m.optionsLoader = options.NewLoader(m.ghClient, remote)
+
+	if cfg != nil {
 		m.wfdConfig = cfg
+		m.workflows = cfg.ApplyOverrides(workflows)
 		m.rightPanel.SetChains(cfg.Chains)
 	}
 
-	if len(workflows) > 0 {
+	if len(m.workflows) > 0 {
 		m.selectedWorkflow = 0
-		m.initializeInputs(workflows[0])
+		m.initializeInputs(m.workflows[0])
 	} else {
 		m.syncHistoryEntries()
+		m.syncPresets()
 	}
 
 	return m
 }
 
+// WithDiscoveryReport attaches the discovery report so parse failures can be
+// surfaced in the workflow pane and status bar.
+func (m Model) WithDiscoveryReport(report *workflow.DiscoveryReport) Model {
+	m.discoveryReport = report
+	return m
+}
+
 // Init implements tea.Model.
-func (Model) Init() tea.Cmd {
-	return nil
+func (m Model) Init() tea.Cmd {
+	return m.pollFiles()
 }
 
 // Update implements tea.Model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Background results apply even while a modal is open.
+	switch msg := msg.(type) {
+	case BranchWorkflowsMsg:
+		return m.handleBranchWorkflows(msg)
+
+	case filesChangedMsg:
+		return m.handleFilesChanged(msg)
+
+	case dispatchDoneMsg:
+		return m.handleDispatchDone(msg)
+
+	case optionsLoadedMsg:
+		return m.handleOptionsLoaded(msg)
+
+	case environmentsLoadedMsg:
+		return m.handleEnvironmentsLoaded(msg)
+
+	case branchesLoadedMsg:
+		return m.handleBranchesLoaded(msg)
+
+	case reloadNoticeExpiredMsg:
+		if msg.seq == m.reloadNoticeSeq {
+			m.reloadNotice = ""
+		}
+
+		return m, nil
+	}
+
 	if m.modalStack.HasActive() {
 		return m.updateModal(msg)
 	}
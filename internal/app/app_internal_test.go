@@ -1,15 +1,30 @@
 package app
 
 import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/exec"
 	"github.com/kyleking/gh-lazydispatch/internal/frecency"
+	"github.com/kyleking/gh-lazydispatch/internal/git"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
+	"github.com/kyleking/gh-lazydispatch/internal/options"
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/runner"
 	"github.com/kyleking/gh-lazydispatch/internal/ui/modal"
+	"github.com/kyleking/gh-lazydispatch/internal/watcher"
 	"github.com/kyleking/gh-lazydispatch/internal/workflow"
 )
 
+var errDispatchFailed = errors.New("HTTP 422: Workflow does not have 'workflow_dispatch' trigger")
+
 const (
 	testInputEnvironment = "environment"
 	testValueStaging     = "staging"
@@ -607,3 +622,871 @@ func contains(s, substr string) bool {
 
 	return false
 }
+
+func TestOpenInputModal_EnvironmentType(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{
+			Inputs: map[string]workflow.Input{"target": {Type: workflow.InputTypeEnvironment}},
+		}},
+	}}
+
+	tests := []struct {
+		name         string
+		environments []github.Environment
+		wantSelect   bool
+	}{
+		{"environments listed", []github.Environment{{Name: "staging"}, {Name: "production"}}, true},
+		{"no environments falls back to text", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := New(workflows, frecency.NewStore(), "owner/repo")
+			m.ghClient = nil
+			m.environments = tt.environments
+			m.environmentsLoaded = true
+
+			result, _ := m.openInputModalForName("target")
+			m = asModel(t, result)
+
+			_, isSelect := m.modalStack.Current().(*modal.SelectModal)
+			if isSelect != tt.wantSelect {
+				t.Errorf("SelectModal pushed = %v, want %v (got %T)", isSelect, tt.wantSelect, m.modalStack.Current())
+			}
+		})
+	}
+}
+
+func TestOpenInputModal_EnvironmentsLoadInBackground(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{
+			Inputs: map[string]workflow.Input{"target": {Type: workflow.InputTypeEnvironment}},
+		}},
+	}}
+
+	tests := []struct {
+		name       string
+		stdout     string
+		err        error
+		wantSelect bool
+	}{
+		{"listed environments replace the picker", `{"total_count": 1, "environments": [{"name": "staging"}]}`, nil, true},
+		{"listing failure falls back to text", "", exec.ErrMockExitStatus1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockExec := exec.NewMockExecutor()
+			mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/environments?per_page=100&page=1"}, tt.stdout, "", tt.err)
+
+			client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			m := New(workflows, frecency.NewStore(), "owner/repo")
+			m.ghClient = client
+
+			result, cmd := m.openInputModalForName("target")
+			m = asModel(t, result)
+
+			if _, ok := m.modalStack.Current().(*modal.PickerModal); !ok {
+				t.Fatalf("expected a loading PickerModal, got %T", m.modalStack.Current())
+			}
+
+			if cmd == nil {
+				t.Fatal("expected a command listing the environments")
+			}
+
+			if len(mockExec.ExecutedCommands) != 0 {
+				t.Error("environments were listed before the command ran")
+			}
+
+			result, _ = m.Update(cmd())
+			m = asModel(t, result)
+
+			_, isSelect := m.modalStack.Current().(*modal.SelectModal)
+			if isSelect != tt.wantSelect {
+				t.Errorf("SelectModal pushed = %v, want %v (got %T)", isSelect, tt.wantSelect, m.modalStack.Current())
+			}
+
+			m.modalStack.Pop()
+
+			if m.modalStack.HasActive() {
+				t.Error("expected the loading picker to be replaced, not stacked under")
+			}
+
+			if again := m.loadEnvironments(); again != nil {
+				t.Error("environments were listed again after being cached")
+			}
+		})
+	}
+}
+
+func TestOpenInputModal_NumberType(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "scale.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{
+			Inputs: map[string]workflow.Input{"replicas": {Type: workflow.InputTypeNumber, Default: "2"}},
+		}},
+	}}
+
+	m := New(workflows, frecency.NewStore(), "owner/repo")
+
+	result, _ := m.openInputModalForName("replicas")
+	m = asModel(t, result)
+
+	if _, ok := m.modalStack.Current().(*modal.NumberInputModal); !ok {
+		t.Errorf("expected NumberInputModal, got %T", m.modalStack.Current())
+	}
+
+	for _, value := range []string{"many", "NaN", "Inf"} {
+		m.inputs["replicas"] = value
+
+		if errs := m.validateAllInputs(workflows[0]); len(errs["replicas"]) != 1 {
+			t.Errorf("expected %q to fail validation, got %v", value, errs)
+		}
+	}
+
+	if got := m.buildCLIString(); !contains(got, "-f replicas=Inf") {
+		t.Errorf("expected number input passed as a raw field, got %q", got)
+	}
+}
+
+func TestValidateAllInputs_CrossFieldRules(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"environment": {Default: "staging"},
+			"ticket": {ValidationRules: []rule.ValidationRule{
+				{Type: rule.RuleRequiredIf, Field: "environment", Pattern: "production"},
+			}},
+		}}},
+	}}
+
+	m := New(workflows, frecency.NewStore(), "owner/repo")
+
+	if errs := m.validateAllInputs(workflows[0]); len(errs) != 0 {
+		t.Errorf("expected no errors outside production, got %v", errs)
+	}
+
+	m.inputs["environment"] = "production"
+
+	errs := m.validateAllInputs(workflows[0])
+	if len(errs["ticket"]) != 1 || !contains(errs["ticket"][0], "required_if:environment=production") {
+		t.Errorf("expected ticket to fail its required_if rule, got %v", errs)
+	}
+}
+
+func TestOpenInputModal_OptionsProvider(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"environment": {OptionsProvider: &workflow.OptionsProvider{Kind: workflow.OptionsCommand, Arg: "./envs.sh"}},
+			"region":      {OptionsProvider: &workflow.OptionsProvider{Kind: workflow.OptionsCommand, Arg: "./regions.sh"}},
+		}}},
+	}}
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("./envs.sh", nil, "staging\nproduction\n", "", nil)
+
+	root := t.TempDir()
+
+	m := New(workflows, frecency.NewStore(), "owner/repo").WithReload(root)
+	m.wfdConfig = &config.WfdConfig{Options: config.OptionsSettings{Commands: true}}
+	m.optionsLoader = options.NewLoaderWithRunner(nil, false, mockExec)
+
+	result, cmd := m.openInputModalForName("environment")
+	m = asModel(t, result)
+
+	picker, ok := m.modalStack.Current().(*modal.PickerModal)
+	if !ok || cmd == nil {
+		t.Fatalf("expected a loading PickerModal and a command listing options, got %T", m.modalStack.Current())
+	}
+
+	if view := picker.View(); !contains(view, "Loading options") {
+		t.Errorf("expected the picker to show it is loading:\n%s", view)
+	}
+
+	result, _ = m.Update(cmd())
+	m = asModel(t, result)
+
+	if view := picker.View(); !contains(view, "production") {
+		t.Errorf("expected the listed options in the picker:\n%s", view)
+	}
+
+	if dir := mockExec.ExecutedCommands[0].Dir; dir != root {
+		t.Errorf("expected the command to run from the repository root %q, got %q", root, dir)
+	}
+
+	result, _ = m.handleSelectResult(modal.SelectResultMsg{Value: "production"})
+	m = asModel(t, result)
+	m.modalStack.Clear()
+
+	if m.inputs["environment"] != "production" {
+		t.Errorf("expected the picked option to be set, got %q", m.inputs["environment"])
+	}
+
+	// ./regions.sh is not configured, so listing fails and free text is offered.
+	result, cmd = m.openInputModalForName("region")
+	m = asModel(t, result)
+	result, _ = m.Update(cmd())
+	m = asModel(t, result)
+
+	inputModal, ok := m.modalStack.Current().(*modal.InputModal)
+	if !ok {
+		t.Fatalf("expected InputModal fallback, got %T", m.modalStack.Current())
+	}
+
+	if view := inputModal.View(); !contains(view, "Could not list options") {
+		t.Errorf("expected the listing error in the fallback modal:\n%s", view)
+	}
+}
+
+func TestOpenInputModal_CommandOptionsNeedOptIn(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"environment": {OptionsProvider: &workflow.OptionsProvider{Kind: workflow.OptionsCommand, Arg: "./envs.sh"}},
+		}}},
+	}}
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("./envs.sh", nil, "staging\n", "", nil)
+
+	m := New(workflows, frecency.NewStore(), "owner/repo")
+	m.wfdConfig = nil
+	m.optionsLoader = options.NewLoaderWithRunner(nil, false, mockExec)
+
+	result, cmd := m.openInputModalForName("environment")
+	m = asModel(t, result)
+	result, _ = m.Update(cmd())
+	m = asModel(t, result)
+
+	if len(mockExec.ExecutedCommands) != 0 {
+		t.Errorf("expected no command to run without options.commands, ran %v", mockExec.ExecutedCommands)
+	}
+
+	inputModal, ok := m.modalStack.Current().(*modal.InputModal)
+	if !ok || !contains(inputModal.View(), "options.commands: true") {
+		t.Errorf("expected free text with a hint to opt in, got %T", m.modalStack.Current())
+	}
+}
+
+func TestHandleBranchWorkflows(t *testing.T) {
+	t.Parallel()
+
+	local := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"env":     {Default: "dev"},
+			"retired": {},
+		}}},
+	}}
+	remote := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"env":     {Default: "dev"},
+			"version": {Required: true},
+		}}},
+	}}
+
+	m := New(local, frecency.NewStore(), "owner/repo")
+	m.inputs["env"] = "staging"
+	m.branch = "feature"
+
+	result, _ := m.handleBranchWorkflows(BranchWorkflowsMsg{
+		Branch:   "feature",
+		Report:   &workflow.DiscoveryReport{Dispatchable: remote},
+		StaleRef: "origin/feature",
+	})
+	m = asModel(t, result)
+
+	if note := m.staleRefNote(); !contains(note, "origin/feature as of the last git fetch") {
+		t.Errorf("expected a stale ref note, got %q", note)
+	}
+
+	if m.inputs["env"] != "staging" {
+		t.Errorf("expected edited value for unchanged input to be kept, got %q", m.inputs["env"])
+	}
+
+	if m.inputChanges["version"] != workflow.InputAdded || m.inputChanges["retired"] != workflow.InputRemoved {
+		t.Errorf("unexpected input changes: %v", m.inputChanges)
+	}
+
+	if _, ok := m.inputs["retired"]; ok {
+		t.Error("expected input removed on the branch to be dropped")
+	}
+
+	m.branch = "main"
+	result, _ = m.handleBranchWorkflows(BranchWorkflowsMsg{Branch: "main", Err: git.ErrRefNotFound})
+	m = asModel(t, result)
+
+	if m.inputChanges != nil || len(m.workflows[0].GetInputs()) != 2 {
+		t.Errorf("expected fallback to local workflows, got changes %v", m.inputChanges)
+	}
+
+	if !contains(m.reloadNotice, "showing local files") || m.staleRefNote() != "" {
+		t.Errorf("expected a fallback notice and no stale ref note, got %q", m.reloadNotice)
+	}
+}
+
+func TestNewRemote(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "ops.yml",
+		On:       workflow.OnTrigger{Dispatch: &workflow.Dispatch{}},
+	}}
+
+	m := NewRemote(workflows, frecency.NewStore(), "owner/ops", "trunk", nil)
+
+	if m.branch != "trunk" {
+		t.Errorf("expected branch trunk, got %q", m.branch)
+	}
+
+	if got := m.buildCLIString(); got != "gh workflow run ops.yml --repo owner/ops --ref trunk" {
+		t.Errorf("unexpected command %q", got)
+	}
+}
+
+func TestOpenBranchModal_ListsBranchesInBackground(t *testing.T) {
+	t.Parallel()
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/ops/branches?per_page=100"},
+		`[{"name": "trunk"}, {"name": "release"}]`, "", nil)
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/ops"}, `{"default_branch": "trunk"}`, "", nil)
+
+	client, err := github.NewClientWithExecutor("owner/ops", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	m := NewRemote(nil, frecency.NewStore(), "owner/ops", "feature", nil)
+	m.ghClient = client
+
+	result, cmd := m.openBranchModal()
+	m = asModel(t, result)
+
+	branchModal, ok := m.modalStack.Current().(*modal.SimpleBranchModal)
+	if !ok {
+		t.Fatalf("expected a SimpleBranchModal, got %T", m.modalStack.Current())
+	}
+
+	if len(mockExec.ExecutedCommands) != 0 {
+		t.Error("branches were listed before the command ran")
+	}
+
+	result, _ = m.Update(cmd())
+	m = asModel(t, result)
+
+	view := branchModal.View()
+	for _, want := range []string{"trunk", "release", "feature"} {
+		if !contains(view, want) {
+			t.Errorf("expected %q listed, got:\n%s", want, view)
+		}
+	}
+
+	if contains(view, "Loading branches") {
+		t.Error("expected the loading state to end")
+	}
+}
+
+func TestHandleFilesChanged(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, ".github", "workflows")
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(name, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("deploy.yml", `on:
+  workflow_dispatch:
+    inputs:
+      env:
+        default: dev
+      debug:
+        default: "false"
+`)
+
+	report, err := workflow.Discover(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(report.Dispatchable, frecency.NewStore(), "owner/repo").WithReload(root)
+	m.inputs["env"] = "prod"
+
+	write("deploy.yml", `on:
+  workflow_dispatch:
+    inputs:
+      env:
+        default: dev
+      region:
+        default: us-east-1
+`)
+	write("broken.yml", "on: [\n")
+
+	result, cmd := m.handleFilesChanged(filesChangedMsg{
+		Paths: []string{".github/workflows/broken.yml", ".github/workflows/deploy.yml"},
+	})
+	m = asModel(t, result)
+
+	if cmd == nil {
+		t.Error("expected polling to continue")
+	}
+
+	if m.inputs["env"] != "prod" {
+		t.Errorf("expected entered value to survive reload, got %q", m.inputs["env"])
+	}
+
+	if _, ok := m.inputs["debug"]; ok || m.inputs["region"] != "us-east-1" {
+		t.Errorf("expected inputs to follow the edited file, got %v", m.inputs)
+	}
+
+	if m.reloadNotice != "Reloaded 2 file(s) / 1 error(s)" {
+		t.Errorf("unexpected notice %q", m.reloadNotice)
+	}
+
+	result, _ = m.Update(reloadNoticeExpiredMsg{seq: m.reloadNoticeSeq})
+	if notice := asModel(t, result).reloadNotice; notice != "" {
+		t.Errorf("expected notice to expire, got %q", notice)
+	}
+}
+
+func TestHandleFilesChanged_ValuesFile(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	write := func(rel, content string) {
+		t.Helper()
+
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(".github/workflows/deploy.yml", `on:
+  workflow_dispatch:
+    inputs:
+      region:
+        # lazydispatch:validate:oneof-file:deploy/regions.txt
+        default: us-east-1
+`)
+	write("deploy/regions.txt", "us-east-1\n")
+
+	report, err := workflow.Discover(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(report.Dispatchable, frecency.NewStore(), "owner/repo").WithReload(root)
+
+	if got := m.valuesFiles(); !slices.Equal(got, []string{"deploy/regions.txt"}) {
+		t.Fatalf("valuesFiles() = %v", got)
+	}
+
+	write("deploy/regions.txt", "us-east-1\neu-west-1\n")
+
+	changed := m.fileWatcher.Poll()
+	if !slices.Equal(changed, []string{"deploy/regions.txt"}) {
+		t.Fatalf("expected the values file edit to be polled, got %v", changed)
+	}
+
+	result, _ := m.handleFilesChanged(filesChangedMsg{Paths: changed})
+	m = asModel(t, result)
+
+	rules := m.workflows[0].GetInputs()["region"].ValidationRules
+	if len(rules) != 1 || !slices.Equal(rules[0].Values, []string{"us-east-1", "eu-west-1"}) {
+		t.Errorf("expected the reloaded values, got %+v", rules)
+	}
+}
+
+func TestApplyWorkflows_InputOverrides(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte(`version: 2
+workflows:
+  deploy.yml:
+    inputs:
+      version:
+        label: Release version
+        order: 1
+      env:
+        default: production
+        locked: true
+      token_scope:
+        default: read
+        hidden: true
+`))
+	if err != nil {
+		t.Fatalf("config.Parse failed: %v", err)
+	}
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"env":         {Default: "dev"},
+			"version":     {},
+			"token_scope": {},
+			"dry_run":     {Default: "false"},
+		}}},
+	}}
+
+	m := New(workflows, frecency.NewStore(), "owner/repo")
+	m.wfdConfig = cfg
+	m = m.applyWorkflows(workflows, "")
+
+	if want := []string{"version", "dry_run", "env"}; !slices.Equal(m.inputOrder, want) {
+		t.Errorf("inputOrder = %v, want %v", m.inputOrder, want)
+	}
+
+	if m.inputs["env"] != "production" || m.inputs["token_scope"] != "read" {
+		t.Errorf("expected default overrides, got %v", m.inputs)
+	}
+
+	if got := m.buildCLIString(); !contains(got, "token_scope=read") {
+		t.Errorf("expected hidden input to be dispatched, got %q", got)
+	}
+
+	result, _ := m.openInputModalForName("env")
+	if m = asModel(t, result); m.modalStack.HasActive() {
+		t.Errorf("expected locked input not to open an editor, got %T", m.modalStack.Current())
+	}
+
+	m.width, m.height = 160, 40
+	if pane := m.viewConfigPane(120, 30); !contains(pane, "Release version") || !contains(pane, "[locked]") {
+		t.Errorf("expected label and lock marker in config pane:\n%s", pane)
+	}
+}
+
+func TestHandleRemapResult_AppliesDecisions(t *testing.T) {
+	t.Parallel()
+
+	m := New(nil, frecency.NewStore(), "owner/repo")
+	m.previewingHistoryEntry = &frecency.HistoryEntry{Inputs: map[string]string{
+		"debug": "yes",
+		"env":   "prod",
+		"old":   "x",
+	}}
+
+	result, _ := m.handleRemapResult(modal.RemapResultMsg{Decisions: []modal.RemapDecision{
+		{OriginalName: "debug", NewValue: "true", Action: modal.RemapActionSet},
+		{OriginalName: "env", Action: modal.RemapActionDrop},
+		{OriginalName: "old", NewName: "new", Action: modal.RemapActionMap},
+		{OriginalName: "region", NewValue: "us-east-1", Action: modal.RemapActionSet},
+	}})
+	m = asModel(t, result)
+
+	want := map[string]string{"debug": "true", "new": "x", "region": "us-east-1"}
+	if got := m.previewingHistoryEntry.Inputs; !maps.Equal(got, want) {
+		t.Errorf("inputs = %v, want %v", got, want)
+	}
+}
+
+func TestPresets_LoadAndSave(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	configPath := filepath.Join(root, config.ConfigFilename)
+
+	err := config.SavePreset(configPath, "deploy.yml", "staging-canary", config.Preset{
+		Inputs: map[string]string{"env": "staging", "canary": "yes"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"env":    {Type: "choice", Options: []string{"staging", "production"}, Default: "production"},
+			"canary": {Type: "boolean", Default: "false"},
+		}}},
+	}}
+
+	m := New(workflows, frecency.NewStore(), "owner/repo").WithReload(root)
+	m.reloadConfig(root)
+
+	name, preset, ok := m.rightPanel.SelectedPreset()
+	if !ok || name != "staging-canary" {
+		t.Fatalf("expected the preset to be listed, got %q", name)
+	}
+
+	// "yes" no longer fits the boolean input, so the remap wizard runs first.
+	result, _ := m.loadPreset(preset)
+	m = asModel(t, result)
+
+	remap, ok := m.modalStack.Current().(*modal.RemapModal)
+	if !ok {
+		t.Fatalf("expected RemapModal, got %T", m.modalStack.Current())
+	}
+
+	_, cmd := remap.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m.modalStack.Clear()
+
+	result, _ = m.Update(cmd())
+	m = asModel(t, result)
+
+	if want := map[string]string{"env": "staging", "canary": "true"}; !maps.Equal(m.inputs, want) {
+		t.Fatalf("inputs = %v, want %v", m.inputs, want)
+	}
+
+	if m.focused != PaneConfig {
+		t.Error("expected loading a preset to focus the config pane")
+	}
+
+	m.inputs["env"] = "production"
+	m.branch = "release"
+
+	result, _ = m.openSavePresetModal()
+	m = asModel(t, result)
+	m.modalStack.Clear()
+
+	result, _ = m.handleInputResult(modal.InputResultMsg{Value: "prod-canary"})
+	m = asModel(t, result)
+
+	cfg, err := config.LoadFrom(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// env is back at its default, so only canary is saved.
+	saved := cfg.Presets("deploy.yml")["prod-canary"]
+	if !maps.Equal(saved.Inputs, map[string]string{"canary": "true"}) {
+		t.Errorf("saved inputs = %v", saved.Inputs)
+	}
+
+	if saved.Branch != "release" {
+		t.Errorf("saved branch = %q, want release", saved.Branch)
+	}
+
+	if _, _, ok := m.rightPanel.SelectedPreset(); !ok || m.reloadNotice != "Saved preset prod-canary" {
+		t.Errorf("expected the saved preset to be listed with a notice, got %q", m.reloadNotice)
+	}
+}
+
+func TestExecuteWorkflow_SendsExplicitInputs(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"note":     {Type: "string", Default: "from default"},
+			"tag":      {Type: "string"},
+			"replicas": {Type: "number", Default: "2"},
+		}}},
+	}}
+
+	m := New(workflows, frecency.NewStore(), "owner/repo")
+	m.selectedWorkflow = 0
+	m.initializeInputs(workflows[0])
+	m.inputs["note"] = ""
+
+	result, _ := m.executeWorkflow()
+	m = asModel(t, result)
+
+	confirm, ok := m.modalStack.Current().(*modal.RunConfirmModal)
+	if !ok {
+		t.Fatalf("expected RunConfirmModal, got %T", m.modalStack.Current())
+	}
+
+	view := confirm.View()
+	for _, want := range []string{`"note": ""`, `"replicas": 2`} {
+		if !contains(view, want) {
+			t.Errorf("request body is missing %s:\n%s", want, view)
+		}
+	}
+
+	if contains(view, `"tag"`) {
+		t.Errorf("an empty input without a default should be left unset:\n%s", view)
+	}
+
+	// A value for an input the workflow no longer declares is refused.
+	m.modalStack.Clear()
+	m.inputs["removed"] = "x"
+
+	result, _ = m.executeWorkflow()
+	m = asModel(t, result)
+
+	if _, ok := m.modalStack.Current().(*modal.ErrorModal); !ok {
+		t.Errorf("expected ErrorModal for an undeclared input, got %T", m.modalStack.Current())
+	}
+}
+
+func TestHandleDispatchDone(t *testing.T) {
+	t.Parallel()
+
+	client, err := github.NewClientWithExecutor("owner/repo", exec.NewMockExecutor())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	m := New(nil, frecency.NewStore(), "owner/repo")
+	m.watcher = watcher.NewWatcher(client)
+	t.Cleanup(m.watcher.Stop)
+
+	result, cmd := m.handleDispatchDone(dispatchDoneMsg{
+		Workflow: "deploy.yml",
+		Result:   runner.DispatchResult{RunID: 42, URL: "https://github.com/owner/repo/actions/runs/42"},
+	})
+	m = asModel(t, result)
+
+	if _, watched := m.watcher.GetRun(42); !watched {
+		t.Error("expected the dispatched run to be watched")
+	}
+
+	if !contains(m.reloadNotice, "actions/runs/42") || cmd == nil {
+		t.Errorf("expected a notice with the run URL, got %q", m.reloadNotice)
+	}
+
+	result, _ = m.handleDispatchDone(dispatchDoneMsg{Workflow: "deploy.yml", Err: errDispatchFailed})
+	m = asModel(t, result)
+
+	if _, ok := m.modalStack.Current().(*modal.ErrorModal); !ok {
+		t.Errorf("expected ErrorModal for a failed dispatch, got %T", m.modalStack.Current())
+	}
+}
+
+func TestDoExecuteWorkflow_WithoutClient(t *testing.T) {
+	t.Parallel()
+
+	m := New(nil, frecency.NewStore(), "owner/repo")
+	m.ghClient = nil
+
+	result, cmd := m.doExecuteWorkflow(runner.RunConfig{Workflow: "deploy.yml"})
+	m = asModel(t, result)
+
+	if _, ok := m.modalStack.Current().(*modal.ErrorModal); !ok || cmd != nil {
+		t.Errorf("expected ErrorModal and no command, got %T", m.modalStack.Current())
+	}
+
+	if entries := m.history.TopForRepo("owner/repo", "deploy.yml", 1); len(entries) != 0 {
+		t.Error("a dispatch that never ran should not be recorded")
+	}
+}
+
+func TestHistoryReplay_KeepsLockedAndHiddenInputs(t *testing.T) {
+	t.Parallel()
+
+	workflows := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"env":   {Type: "string", Default: "production", Locked: true},
+			"token": {Type: "string", Default: "from-config", Hidden: true},
+			"note":  {Type: "string", Default: "default note"},
+			"tag":   {Type: "string", Default: "latest"},
+		}}},
+	}}
+
+	m := New(workflows, frecency.NewStore(), "owner/repo")
+	m.branch = "main"
+	m.selectedWorkflow = 0
+	m.initializeInputs(workflows[0])
+
+	// Recorded before env was locked and token hidden; tag was left unset.
+	m.history.Record("owner/repo", "deploy.yml", "main", map[string]string{
+		"env": "staging", "token": "stale", "note": "replayed",
+	})
+	m.syncHistoryEntries()
+
+	m.focused = PaneHistory
+	m.viewMode = HistoryPreviewMode
+
+	result, _ := m.handleEnter()
+	m = asModel(t, result)
+
+	want := map[string]string{"env": "production", "token": "from-config", "note": "replayed", "tag": "latest"}
+	if !maps.Equal(m.inputs, want) {
+		t.Errorf("inputs = %v, want %v", m.inputs, want)
+	}
+}
+
+func TestHistoryReplay_ReloadsWorkflowsOnEntryBranch(t *testing.T) {
+	t.Parallel()
+
+	local := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"env": {Type: "string", Default: "dev"},
+		}}},
+	}}
+	onRelease := []workflow.File{{
+		Filename: "deploy.yml",
+		On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+			"env":     {Type: "string", Default: "dev"},
+			"version": {Type: "string", Default: "1.0"},
+		}}},
+	}}
+
+	m := New(local, frecency.NewStore(), "owner/repo")
+	m.branch = "main"
+	m.selectedWorkflow = 0
+	m.initializeInputs(local[0])
+
+	m.history.Record("owner/repo", "deploy.yml", "release", map[string]string{"env": "prod", "version": "2.0"})
+	m.syncHistoryEntries()
+
+	m.focused = PaneHistory
+	m.viewMode = HistoryPreviewMode
+
+	result, cmd := m.handleEnter()
+	m = asModel(t, result)
+
+	if m.branch != "release" || m.pendingReplay == nil || cmd == nil {
+		t.Fatalf("expected the workflows on release to be loaded first, branch %q", m.branch)
+	}
+
+	if m.modalStack.HasActive() {
+		t.Fatal("the replay should wait for the branch's workflows")
+	}
+
+	result, _ = m.handleBranchWorkflows(BranchWorkflowsMsg{
+		Branch: "release",
+		Report: &workflow.DiscoveryReport{Dispatchable: onRelease},
+	})
+	m = asModel(t, result)
+
+	if want := map[string]string{"env": "prod", "version": "2.0"}; !maps.Equal(m.inputs, want) {
+		t.Errorf("inputs = %v, want %v", m.inputs, want)
+	}
+
+	if _, ok := m.modalStack.Current().(*modal.RunConfirmModal); !ok {
+		t.Errorf("expected RunConfirmModal once the workflows loaded, got %T", m.modalStack.Current())
+	}
+}
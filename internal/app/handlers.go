@@ -3,8 +3,10 @@ package app
 import (
 	"context"
 	"errors"
-	"os/exec"
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
@@ -14,7 +16,9 @@ import (
 	"github.com/kyleking/gh-lazydispatch/internal/config"
 	"github.com/kyleking/gh-lazydispatch/internal/frecency"
 	"github.com/kyleking/gh-lazydispatch/internal/git"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
 	"github.com/kyleking/gh-lazydispatch/internal/logs"
+	"github.com/kyleking/gh-lazydispatch/internal/options"
 	"github.com/kyleking/gh-lazydispatch/internal/rule"
 	"github.com/kyleking/gh-lazydispatch/internal/runner"
 	"github.com/kyleking/gh-lazydispatch/internal/ui"
@@ -32,9 +36,16 @@ const boolTrueValue = "true"
 
 const inputTypeChoice = "choice"
 
+// optionsLoadTimeout bounds listing an input's options, so a slow git, API
+// call or cmd script leaves the picker with an error instead of loading forever.
+const optionsLoadTimeout = 15 * time.Second
+
 // ErrLogManagerNotInitialized indicates logs were requested before the log manager was set up.
 var ErrLogManagerNotInitialized = errors.New("log manager not initialized")
 
+// ErrNoGitHubClient indicates a dispatch was requested without a GitHub client for the repository.
+var ErrNoGitHubClient = errors.New("no GitHub client: check that gh is installed and authenticated")
+
 // ErrNoChainStateOrRunID indicates a log fetch request lacked both chain state and a run ID.
 var ErrNoChainStateOrRunID = errors.New("no chain state or run ID provided")
 
@@ -126,6 +137,10 @@ func (m Model) handlePaneKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, bool) {
 	case key.Matches(msg, m.keys.Reset):
 		model, cmd := m.handleConfigPaneAction(m.openResetModal)
 		return model, cmd, true
+
+	case key.Matches(msg, m.keys.Preset):
+		model, cmd := m.handleConfigPaneAction(m.openSavePresetModal)
+		return model, cmd, true
 	}
 
 	return m.handleHistoryPaneKey(msg)
@@ -305,6 +320,8 @@ func (m *Model) handleUp() {
 			m.rightPanel.Chains().MoveUp()
 		case panes.TabLive:
 			m.rightPanel.Live().MoveUp()
+		case panes.TabPresets:
+			m.rightPanel.Presets().MoveUp()
 		}
 	case PaneConfig:
 		if m.selectedInput < 0 {
@@ -339,6 +356,8 @@ func (m *Model) handleDown() {
 			m.rightPanel.Chains().MoveDown()
 		case panes.TabLive:
 			m.rightPanel.Live().MoveDown()
+		case panes.TabPresets:
+			m.rightPanel.Presets().MoveDown()
 		}
 	case PaneConfig:
 		if m.selectedInput < 0 {
@@ -366,26 +385,36 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			entry := m.rightPanel.SelectedHistoryEntry()
 			if entry != nil {
 				if m.viewMode == HistoryPreviewMode {
-					m.branch = entry.Branch
-					m.inputs = make(map[string]string)
-
-					for k, v := range entry.Inputs {
-						m.inputs[k] = v
-					}
-
 					m.viewMode = WorkflowListMode
 					m.previewingHistoryEntry = nil
 
-					return m.executeWorkflow()
+					if entry.Branch != "" && entry.Branch != m.branch {
+						// Validate and dispatch against the workflow as it is on
+						// the entry's branch, once it has been read.
+						m.branch = entry.Branch
+						m.pendingReplay = entry
+
+						return m, m.loadWorkflowsAtBranch(entry.Branch)
+					}
+
+					return m.replayHistoryEntry(*entry)
 				}
 
 				m.viewMode = HistoryPreviewMode
 				m.previewingHistoryEntry = entry
+
+				if wf := m.SelectedWorkflow(); wf != nil && hasEnvironmentInput(*wf) {
+					return m, m.loadEnvironments()
+				}
 			}
 		case panes.TabChains:
 			if name, chainDef, ok := m.rightPanel.SelectedChain(); ok {
 				return m.startChainFlow(name, chainDef)
 			}
+		case panes.TabPresets:
+			if _, preset, ok := m.rightPanel.SelectedPreset(); ok {
+				return m.loadPreset(preset)
+			}
 		}
 	case PaneConfig:
 		return m.executeWorkflow()
@@ -394,6 +423,22 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// replayHistoryEntry fills the inputs from a history entry and opens the run
+// confirmation, or the validation errors, for it.
+func (m Model) replayHistoryEntry(entry frecency.HistoryEntry) (tea.Model, tea.Cmd) {
+	wf := m.SelectedWorkflow()
+	if wf == nil || (entry.Workflow != "" && wf.Filename != entry.Workflow) {
+		m.modalStack.Push(modal.NewErrorModal("Cannot Replay "+entry.Workflow,
+			entry.Workflow+" is not a dispatchable workflow on "+m.branch+"."))
+
+		return m, nil
+	}
+
+	m.fillInputs(*wf, entry.Inputs)
+
+	return m.executeWorkflow()
+}
+
 func (m Model) startChainFlow(name string, chainDef config.Chain) (tea.Model, tea.Cmd) {
 	m.pendingChainName = name
 	m.pendingChain = &chainDef
@@ -477,7 +522,7 @@ func (m Model) handleChainConfirmResult(msg modal.ChainConfirmResultMsg) (tea.Mo
 	return m, m.chainSubscription()
 }
 
-func (Model) buildChainCommands(chainDef *config.Chain, variables map[string]string, branch string) []string {
+func (m Model) buildChainCommands(chainDef *config.Chain, variables map[string]string, branch string) []string {
 	commands := make([]string, len(chainDef.Steps))
 
 	ctx := &chain.InterpolationContext{
@@ -493,6 +538,7 @@ func (Model) buildChainCommands(chainDef *config.Chain, variables map[string]str
 			Workflow: step.Workflow,
 			Branch:   branch,
 			Inputs:   inputs,
+			Repo:     m.dispatchRepo(),
 		}
 		args := runner.BuildArgs(cfg)
 		commands[i] = runner.FormatCommand(args)
@@ -532,16 +578,35 @@ func (m Model) executeWorkflow() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	cfg := runner.RunConfig{
-		Workflow: wf.Filename,
-		Branch:   m.branch,
-		Inputs:   m.inputs,
-		Watch:    m.watchRun,
+	m.pushRunConfirm(wf)
+
+	return m, nil
+}
+
+// runConfig returns the dispatch of wf with the current inputs, leaving out
+// those that take their default.
+func (m Model) runConfig(wf workflow.File) runner.RunConfig {
+	return runner.RunConfig{
+		Workflow:   wf.Filename,
+		Branch:     m.branch,
+		Inputs:     runner.ExplicitInputs(wf, m.inputs),
+		InputTypes: inputTypes(wf),
+		Repo:       m.dispatchRepo(),
+		Watch:      m.watchRun,
+	}
+}
+
+// pushRunConfirm opens the run confirmation for wf, or an error if its
+// inputs cannot be encoded, such as one the workflow no longer declares.
+func (m *Model) pushRunConfirm(wf workflow.File) {
+	cfg := m.runConfig(wf)
+
+	if _, err := runner.BuildPayload(cfg); err != nil {
+		m.modalStack.Push(modal.NewErrorModal("Cannot Dispatch "+wf.Filename, err.Error()))
+		return
 	}
 
 	m.modalStack.Push(modal.NewRunConfirmModal(cfg))
-
-	return m, nil
 }
 
 func (m Model) validateAllInputs(wf workflow.File) map[string][]string {
@@ -549,42 +614,94 @@ func (m Model) validateAllInputs(wf workflow.File) map[string][]string {
 
 	inputs := wf.GetInputs()
 	for name, input := range inputs {
+		var validationErrs []string
+
+		if input.InputType() == workflow.InputTypeNumber && !isNumber(m.inputs[name]) {
+			validationErrs = append(validationErrs, "must be a number")
+		}
+
 		if rules := input.ValidationRules; len(rules) > 0 {
-			if validationErrs := rule.ValidateValue(m.inputs[name], rules); len(validationErrs) > 0 {
-				errs[name] = validationErrs
-			}
+			validationErrs = append(validationErrs, rule.ValidateValue(m.inputs[name], rules, m.inputs)...)
+		}
+
+		if len(validationErrs) > 0 {
+			errs[name] = validationErrs
 		}
 	}
 
 	return errs
 }
 
-type executionDoneMsg struct {
-	err error
+// openBranchModal opens the branch modal in its loading state and lists the
+// branches in the background, so neither git nor the API blocks Update.
+func (m Model) openBranchModal() (tea.Model, tea.Cmd) {
+	branchModal := modal.NewSimpleBranchModal("Select Branch", nil, m.branch, "").Loading()
+	branchModal.SetSize(m.width, m.height)
+	m.modalStack.Push(branchModal)
+
+	return m, func() tea.Msg {
+		branches, defaultBranch := m.listBranches()
+		return branchesLoadedMsg{Modal: branchModal, Branches: branches, DefaultBranch: defaultBranch}
+	}
 }
 
-//nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
-func (m Model) openBranchModal() (tea.Model, tea.Cmd) {
-	ctx := context.Background()
+// branchesLoadedMsg carries the branches listed for the branch modal that
+// asked for them.
+type branchesLoadedMsg struct {
+	Modal         *modal.SimpleBranchModal
+	DefaultBranch string
+	Branches      []string
+}
 
-	branches, err := git.FetchBranches(ctx)
-	if err != nil {
-		branches = git.DefaultBranches()
+// handleBranchesLoaded fills the branch modal waiting for msg's branches,
+// keeping the selected branch listed even if it was not found. Results for a
+// modal closed in the meantime are dropped.
+//
+//nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
+func (m Model) handleBranchesLoaded(msg branchesLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.modalStack.Current() != msg.Modal {
+		return m, nil
 	}
 
+	branches := msg.Branches
 	if m.branch != "" && !_contains(branches, m.branch) {
 		branches = append(branches, m.branch)
 	}
 
-	defaultBranch := git.GetDefaultBranch(ctx)
-
-	branchModal := modal.NewSimpleBranchModal("Select Branch", branches, m.branch, defaultBranch)
-	branchModal.SetSize(m.width, m.height)
-	m.modalStack.Push(branchModal)
+	msg.Modal.SetBranches(branches, msg.DefaultBranch)
 
 	return m, nil
 }
 
+// listBranches returns the branches offered in the branch modal and the
+// repository's default branch, from the API in remote mode and git otherwise.
+//
+//nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
+func (m Model) listBranches() ([]string, string) {
+	if m.remote && m.ghClient != nil {
+		branches, err := m.ghClient.ListBranches()
+		if err != nil || len(branches) == 0 {
+			branches = git.DefaultBranches()
+		}
+
+		sort.Strings(branches)
+
+		//nolint:errcheck // best-effort: an unknown default branch just leaves nothing marked
+		defaultBranch, _ := m.ghClient.GetDefaultBranch()
+
+		return branches, defaultBranch
+	}
+
+	ctx := context.Background()
+
+	branches, err := git.FetchBranches(ctx)
+	if err != nil {
+		branches = git.DefaultBranches()
+	}
+
+	return branches, git.GetDefaultBranch(ctx)
+}
+
 //nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
 func (m Model) openLiveViewModal() (tea.Model, tea.Cmd) {
 	if m.watcher == nil {
@@ -616,8 +733,9 @@ func (m Model) openInputModalForName(name string) (tea.Model, tea.Cmd) {
 	wf := m.workflows[m.selectedWorkflow]
 	inputs := wf.GetInputs()
 
+	// Locked inputs always dispatch their configured value.
 	input, ok := inputs[name]
-	if !ok {
+	if !ok || input.Locked {
 		return m, nil
 	}
 
@@ -625,21 +743,230 @@ func (m Model) openInputModalForName(name string) (tea.Model, tea.Cmd) {
 	currentVal := m.inputs[name]
 
 	switch input.InputType() {
-	case "boolean":
+	case workflow.InputTypeBoolean:
 		current := currentVal == boolTrueValue
 		defaultVal := input.Default == boolTrueValue
 		m.modalStack.Push(modal.NewConfirmModal(name, input.Description, current, defaultVal))
 	case inputTypeChoice:
 		m.modalStack.Push(modal.NewSelectModal(name, input.Options, currentVal, input.Default))
+	case workflow.InputTypeNumber:
+		m.modalStack.Push(modal.NewNumberInputModal(
+			name, input.Description, input.Default, currentVal, input.ValidationRules,
+		).WithInputs(m.inputs))
+	case workflow.InputTypeEnvironment:
+		if !m.environmentsLoaded && m.ghClient != nil {
+			picker := modal.NewPickerModal(name, "repository environments", nil, currentVal, input.Default).Loading()
+			picker.SetSize(m.width, m.height)
+			m.modalStack.Push(picker)
+			m.environmentsPicker = &environmentsPicker{picker: picker, input: input}
+
+			return m, m.loadEnvironments()
+		}
+
+		if len(m.environments) > 0 {
+			m.modalStack.Push(newEnvironmentSelectModal(name, m.environments, currentVal, input.Default))
+		} else {
+			m.pushTextInputModal(name, input, currentVal)
+		}
 	default:
-		m.modalStack.Push(modal.NewInputModal(
-			name, input.Description, input.Default, input.InputType(), currentVal, input.Options, input.ValidationRules,
-		))
+		if input.OptionsProvider != nil && m.optionsLoader != nil {
+			return m, m.pushOptionsModal(name, input, currentVal)
+		}
+
+		m.pushTextInputModal(name, input, currentVal)
+	}
+
+	return m, nil
+}
+
+// pushOptionsModal opens a picker over the values listed by the input's
+// options provider. The picker shows a loading state while they are listed
+// in the background, under optionsLoadTimeout.
+func (m *Model) pushOptionsModal(name string, input workflow.Input, currentVal string) tea.Cmd {
+	provider := *input.OptionsProvider
+
+	picker := modal.NewPickerModal(name, provider.Describe(), nil, currentVal, input.Default).Loading()
+	picker.SetSize(m.width, m.height)
+	m.modalStack.Push(picker)
+
+	// cmd providers run only when lazydispatch.yml opts in, and only for the
+	// working copy's workflows: one read from another branch could carry a
+	// command nobody in this checkout has reviewed.
+	commandDir := ""
+	if m.wfdConfig.CommandsAllowed() && !m.remote && m.workflowsRef == "" {
+		commandDir = m.configRoot()
+	}
+
+	loader := m.optionsLoader
+	loader.AllowCommands(commandDir)
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), optionsLoadTimeout)
+		defer cancel()
+
+		values, err := loader.Load(ctx, provider)
+
+		return optionsLoadedMsg{Picker: picker, Input: input, Name: name, Current: currentVal, Options: values, Err: err}
+	}
+}
+
+// optionsLoadedMsg carries the values listed for the picker that asked for them.
+type optionsLoadedMsg struct {
+	Err     error
+	Picker  *modal.PickerModal
+	Name    string
+	Current string
+	Input   workflow.Input
+	Options []string
+}
+
+// handleOptionsLoaded fills the picker waiting for msg's options. When they
+// could not be listed, the picker is swapped for the free-text editor, with
+// the reason in its description. Results for a picker that was closed in
+// the meantime are dropped.
+func (m Model) handleOptionsLoaded(msg optionsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.modalStack.Current() != msg.Picker {
+		return m, nil
+	}
+
+	if msg.Err == nil {
+		msg.Picker.SetOptions(msg.Options)
+		return m, nil
+	}
+
+	m.modalStack.Pop()
+
+	hint := ""
+	if errors.Is(msg.Err, options.ErrCommandsDisabled) {
+		hint = " (set options.commands: true in " + config.ConfigFilename + " to run it)"
+	}
+
+	input := msg.Input
+	input.Description = strings.TrimSpace(input.Description + "\nCould not list options: " + msg.Err.Error() + hint)
+	m.pushTextInputModal(msg.Name, input, msg.Current)
+
+	return m, nil
+}
+
+// pushTextInputModal opens the free-text editor for an input.
+func (m *Model) pushTextInputModal(name string, input workflow.Input, currentVal string) {
+	m.modalStack.Push(modal.NewInputModal(
+		name, input.Description, input.Default, input.InputType(), currentVal, input.Options, input.ValidationRules,
+	).WithInputs(m.inputs))
+}
+
+// newEnvironmentSelectModal lists the repository's environments, annotating
+// each with its protection rules.
+func newEnvironmentSelectModal(
+	name string, environments []github.Environment, current, defaultVal string,
+) *modal.SelectModal {
+	names := make([]string, len(environments))
+	annotations := make(map[string]string, len(environments))
+
+	for i, env := range environments {
+		names[i] = env.Name
+		annotations[env.Name] = env.ProtectionSummary()
+	}
+
+	return modal.NewSelectModal(name, names, current, defaultVal).WithAnnotations(annotations)
+}
+
+// environmentsLoadedMsg carries the repository's environments, listed in the
+// background by loadEnvironments.
+type environmentsLoadedMsg struct {
+	Err          error
+	Environments []github.Environment
+}
+
+// environmentsPicker is the loading picker an environment input opened while
+// the environments were still being listed.
+type environmentsPicker struct {
+	picker *modal.PickerModal
+	input  workflow.Input
+}
+
+// loadEnvironments lists the repository's environments in the background,
+// once per session. It returns nil when they are loaded or being loaded.
+func (m *Model) loadEnvironments() tea.Cmd {
+	if m.environmentsLoaded || m.environmentsLoading || m.ghClient == nil {
+		return nil
+	}
+
+	m.environmentsLoading = true
+	client := m.ghClient
+
+	return func() tea.Msg {
+		envs, err := client.ListEnvironments()
+		return environmentsLoadedMsg{Environments: envs, Err: err}
+	}
+}
+
+// handleEnvironmentsLoaded caches the listed environments for the session.
+// Failures leave the list empty so environment inputs fall back to free text.
+// An environment picker still waiting on the list is swapped for the
+// annotated select, or for the free-text editor when there is nothing to
+// choose from.
+func (m Model) handleEnvironmentsLoaded(msg environmentsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.environmentsLoading = false
+	m.environmentsLoaded = true
+
+	if msg.Err == nil {
+		m.environments = msg.Environments
+	}
+
+	waiting := m.environmentsPicker
+	m.environmentsPicker = nil
+
+	if waiting == nil || m.modalStack.Current() != waiting.picker {
+		return m, nil
+	}
+
+	m.modalStack.Pop()
+
+	name := m.pendingInputName
+	input := waiting.input
+	currentVal := m.inputs[name]
+
+	if len(m.environments) > 0 {
+		m.modalStack.Push(newEnvironmentSelectModal(name, m.environments, currentVal, input.Default))
+		return m, nil
 	}
 
+	if msg.Err != nil {
+		input.Description = strings.TrimSpace(input.Description + "\nCould not list environments: " + msg.Err.Error())
+	}
+
+	m.pushTextInputModal(name, input, currentVal)
+
 	return m, nil
 }
 
+// environmentNames returns the names of the repository's environments, or nil
+// if they have not been loaded, which disables deleted-environment checks.
+func (m Model) environmentNames() []string {
+	if !m.environmentsLoaded || m.environments == nil {
+		return nil
+	}
+
+	names := make([]string, len(m.environments))
+	for i, env := range m.environments {
+		names[i] = env.Name
+	}
+
+	return names
+}
+
+// hasEnvironmentInput returns true if wf declares any environment-type input.
+func hasEnvironmentInput(wf workflow.File) bool {
+	for _, input := range wf.GetInputs() {
+		if input.InputType() == workflow.InputTypeEnvironment {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (m Model) openInputModalFiltered(index int) (tea.Model, tea.Cmd) {
 	if index >= len(m.filteredInputs) {
 		return m, nil
@@ -694,8 +1021,11 @@ func (m Model) openRemapModal() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	m.pendingPreset = nil
 	currentWorkflow := &m.workflows[m.selectedWorkflow]
-	validationErrors := validation.ValidateHistoryConfig(m.previewingHistoryEntry, currentWorkflow)
+	validationErrors := validation.ValidateHistoryConfigWithEnvironments(
+		m.previewingHistoryEntry, currentWorkflow, m.environmentNames(),
+	)
 
 	if len(validationErrors) == 0 {
 		return m, nil
@@ -718,17 +1048,163 @@ func (m Model) handleSelectResult(msg modal.SelectResultMsg) (tea.Model, tea.Cmd
 	return m, nil
 }
 
-//nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
 func (m Model) handleBranchResult(msg modal.BranchResultMsg) (tea.Model, tea.Cmd) {
 	m.branch = msg.Value
+	return m, m.loadWorkflowsAtBranch(msg.Value)
+}
+
+// loadWorkflowsAtBranch discovers the workflows committed at branch, so the
+// inputs shown match what GitHub accepts when dispatching on that ref.
+// In remote mode the files are read through the contents API instead of git.
+func (m Model) loadWorkflowsAtBranch(branch string) tea.Cmd {
+	client, remote := m.ghClient, m.remote
+
+	return func() tea.Msg {
+		var (
+			src      workflow.Source
+			staleRef string
+		)
+
+		if remote && client != nil {
+			src = github.NewContentsSource(client, branch)
+		} else {
+			refSrc, err := git.NewRefSource(context.Background(), branch)
+			if err != nil {
+				return BranchWorkflowsMsg{Branch: branch, Err: err}
+			}
+
+			src = refSrc
+			staleRef = refSrc.Name()
+		}
+
+		report, err := workflow.DiscoverFrom(src)
+
+		return BranchWorkflowsMsg{Branch: branch, Report: report, Err: err, StaleRef: staleRef}
+	}
+}
+
+// handleBranchWorkflows swaps in the workflows discovered at a branch, then
+// carries on with a history replay that was waiting for them. Falls back to
+// the local workflows, with a notice, when the branch could not be read.
+func (m Model) handleBranchWorkflows(msg BranchWorkflowsMsg) (tea.Model, tea.Cmd) {
+	if msg.Branch != m.branch {
+		return m, nil
+	}
+
+	replay := m.pendingReplay
+	m.pendingReplay = nil
+
+	if msg.Err != nil || msg.Report == nil {
+		m = m.applyWorkflows(m.localWorkflows, "")
+		m.staleRef = ""
+
+		reason := "no workflows found"
+		if msg.Err != nil {
+			reason = msg.Err.Error()
+		}
+
+		if replay != nil {
+			m.modalStack.Push(modal.NewErrorModal("Cannot Replay on "+msg.Branch,
+				"The workflows on "+msg.Branch+" could not be read ("+reason+"), "+
+					"so the entry cannot be checked against them."))
+		}
+
+		return m, m.showNotice("Could not read workflows on " + msg.Branch + ", showing local files: " + reason)
+	}
+
+	m = m.applyWorkflows(msg.Report.Dispatchable, msg.Branch)
+	m.staleRef = msg.StaleRef
+
+	if replay != nil {
+		return m.replayHistoryEntry(*replay)
+	}
+
 	return m, nil
 }
 
+// applyWorkflows replaces the listed workflows with workflows read from ref
+// (empty for the working copy), applying the lazydispatch.yml input overrides.
+// The selected workflow and input stay selected, and values the user entered
+// are kept for inputs that still exist.
+func (m Model) applyWorkflows(workflows []workflow.File, ref string) Model {
+	var (
+		selected       string
+		previousInputs map[string]workflow.Input
+	)
+
+	if wf := m.SelectedWorkflow(); wf != nil {
+		selected = wf.Filename
+		previousInputs = wf.GetInputs()
+	}
+
+	previous := m.inputs
+	selectedInput := m.getSelectedInputName()
+
+	m.baseWorkflows = workflows
+	m.workflows = m.wfdConfig.ApplyOverrides(workflows)
+	m.workflowsRef = ref
+	m.selectedWorkflow = -1
+
+	for i, wf := range m.workflows {
+		if wf.Filename == selected {
+			m.selectedWorkflow = i
+			break
+		}
+	}
+
+	if m.selectedWorkflow < 0 && len(m.workflows) > 0 {
+		m.selectedWorkflow = 0
+	}
+
+	if m.selectedWorkflow < 0 {
+		m.inputs = make(map[string]string)
+		m.inputOrder = nil
+		m.filteredInputs = nil
+		m.inputChanges = nil
+		m.selectedInput = -1
+		m.syncHistoryEntries()
+		m.syncPresets()
+
+		return m
+	}
+
+	m.initializeInputs(m.workflows[m.selectedWorkflow])
+
+	if m.workflows[m.selectedWorkflow].Filename != selected {
+		return m
+	}
+
+	for name := range m.inputs {
+		old, existed := previousInputs[name]
+		if val, ok := previous[name]; ok && existed && val != old.Default {
+			m.inputs[name] = val
+		}
+	}
+
+	for i, name := range m.filteredInputs {
+		if name == selectedInput {
+			m.selectedInput = i
+			m.viewMode = InputDetailMode
+
+			break
+		}
+	}
+
+	return m
+}
+
 //nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
 func (m Model) handleInputResult(msg modal.InputResultMsg) (tea.Model, tea.Cmd) {
 	if m.pendingInputName != "" {
 		m.inputs[m.pendingInputName] = msg.Value
 		m.pendingInputName = ""
+
+		return m, nil
+	}
+
+	if m.pendingPresetSave {
+		m.pendingPresetSave = false
+		return m.savePreset(msg.Value)
 	}
 
 	return m, nil
@@ -778,17 +1254,32 @@ func (m Model) handleRunConfirmResult(msg modal.RunConfirmResultMsg) (tea.Model,
 
 //nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
 func (m Model) handleRemapResult(msg modal.RemapResultMsg) (tea.Model, tea.Cmd) {
+	if m.pendingPreset != nil {
+		preset := *m.pendingPreset
+		preset.Inputs = applyRemapDecisions(preset.Inputs, msg.Decisions)
+		m.pendingPreset = nil
+
+		return m.applyPreset(preset)
+	}
+
 	if m.previewingHistoryEntry == nil || len(msg.Decisions) == 0 {
 		return m, nil
 	}
 
+	m.previewingHistoryEntry.Inputs = applyRemapDecisions(m.previewingHistoryEntry.Inputs, msg.Decisions)
+
+	return m, nil
+}
+
+// applyRemapDecisions returns a copy of inputs with the remap wizard's decisions applied.
+func applyRemapDecisions(inputs map[string]string, decisions []modal.RemapDecision) map[string]string {
 	remappedInputs := make(map[string]string)
 
-	for k, v := range m.previewingHistoryEntry.Inputs {
+	for k, v := range inputs {
 		remappedInputs[k] = v
 	}
 
-	for _, decision := range msg.Decisions {
+	for _, decision := range decisions {
 		switch decision.Action {
 		case modal.RemapActionDrop:
 			delete(remappedInputs, decision.OriginalName)
@@ -798,12 +1289,12 @@ func (m Model) handleRemapResult(msg modal.RemapResultMsg) (tea.Model, tea.Cmd)
 				delete(remappedInputs, decision.OriginalName)
 				remappedInputs[decision.NewName] = val
 			}
+		case modal.RemapActionSet:
+			remappedInputs[decision.OriginalName] = decision.NewValue
 		}
 	}
 
-	m.previewingHistoryEntry.Inputs = remappedInputs
-
-	return m, nil
+	return remappedInputs
 }
 
 func (m Model) handleChainSelectResult(msg modal.ChainSelectResultMsg) (tea.Model, tea.Cmd) {
@@ -818,7 +1309,7 @@ func (m Model) handleChainUpdate(msg ChainUpdateMsg) (tea.Model, tea.Cmd) {
 	state := msg.Update.State
 	if state.Status == chain.ChainCompleted || state.Status == chain.ChainFailed {
 		// Convert chain step results to frecency step results for history
-		stepResults := convertToFrecencyStepResults(state.StepResults)
+		stepResults := chain.HistoryResults(state.StepResults)
 
 		// Update history with step results
 		m.history.RecordChain(
@@ -839,37 +1330,6 @@ func (m Model) handleChainUpdate(msg ChainUpdateMsg) (tea.Model, tea.Cmd) {
 	return m, m.chainSubscription()
 }
 
-// convertToFrecencyStepResults converts chain.StepResult to frecency.ChainStepResult.
-func convertToFrecencyStepResults(stepResults map[int]*chain.StepResult) []frecency.ChainStepResult {
-	if len(stepResults) == 0 {
-		return nil
-	}
-
-	// Find the max index to create properly sized slice
-	maxIdx := -1
-	for idx := range stepResults {
-		if idx > maxIdx {
-			maxIdx = idx
-		}
-	}
-
-	results := make([]frecency.ChainStepResult, maxIdx+1)
-
-	for idx, result := range stepResults {
-		if result != nil {
-			status := string(result.Status)
-			results[idx] = frecency.ChainStepResult{
-				Workflow:   result.Workflow,
-				RunID:      result.RunID,
-				Status:     status,
-				Conclusion: result.Conclusion,
-			}
-		}
-	}
-
-	return results
-}
-
 //nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
 func (m Model) handleValidationErrorResult(msg modal.ValidationErrorResultMsg) (tea.Model, tea.Cmd) {
 	if msg.Override {
@@ -877,30 +1337,66 @@ func (m Model) handleValidationErrorResult(msg modal.ValidationErrorResultMsg) (
 			return m, nil
 		}
 
-		wf := m.workflows[m.selectedWorkflow]
-		cfg := runner.RunConfig{
-			Workflow: wf.Filename,
-			Branch:   m.branch,
-			Inputs:   m.inputs,
-			Watch:    m.watchRun,
-		}
-		m.modalStack.Push(modal.NewRunConfirmModal(cfg))
+		m.pushRunConfirm(m.workflows[m.selectedWorkflow])
 	}
 
 	return m, nil
 }
 
+// dispatchDoneMsg carries the outcome of a workflow dispatch started from the TUI.
+type dispatchDoneMsg struct {
+	Err      error
+	Workflow string
+	Result   runner.DispatchResult
+}
+
 func (m Model) doExecuteWorkflow(cfg runner.RunConfig) (tea.Model, tea.Cmd) {
+	if m.ghClient == nil {
+		m.modalStack.Push(modal.NewErrorModal("Cannot Dispatch "+cfg.Workflow, ErrNoGitHubClient.Error()))
+		return m, nil
+	}
+
 	m.history.Record(m.repo, cfg.Workflow, cfg.Branch, cfg.Inputs)
 	//nolint:errcheck,gosec // best-effort persistence; failed history write doesn't block dispatching the workflow
 	m.history.Save()
 
-	//nolint:gosec,noctx // shells out to trusted gh CLI by design; tea.ExecProcess has no context to thread through
-	cmd := exec.Command("gh", runner.BuildArgs(cfg)...)
+	client := m.ghClient
+
+	return m, func() tea.Msg {
+		result, err := runner.Dispatch(cfg, client)
+		return dispatchDoneMsg{Workflow: cfg.Workflow, Result: result, Err: err}
+	}
+}
+
+// handleDispatchDone watches the run a dispatch started in the Live tab and
+// shows its URL, or reports why the dispatch failed.
+func (m Model) handleDispatchDone(msg dispatchDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.modalStack.Push(modal.NewErrorModal("Dispatch Failed", msg.Err.Error()))
+		return m, nil
+	}
+
+	location := msg.Result.URL
+	if location == "" {
+		location = fmt.Sprintf("run %d", msg.Result.RunID)
+	}
+
+	notice := m.showNotice(fmt.Sprintf("Dispatched %s: %s", msg.Workflow, location))
+
+	if m.watcher == nil {
+		return m, notice
+	}
+
+	m.watcher.Watch(msg.Result.RunID, msg.Workflow)
+	m.refreshWatchedRuns()
+
+	if m.runUpdatesSubscribed {
+		return m, notice
+	}
+
+	m.runUpdatesSubscribed = true
 
-	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return executionDoneMsg{err: err}
-	})
+	return m, tea.Batch(notice, m.watcherSubscription())
 }
 
 func (m *Model) applyFilter() {
@@ -945,21 +1441,35 @@ func (m Model) buildCLIString() string {
 		return ""
 	}
 
-	wf := m.workflows[m.selectedWorkflow]
+	// Hidden inputs are left out of the table but still dispatched.
+	return "gh " + strings.Join(runner.BuildArgs(m.runConfig(m.workflows[m.selectedWorkflow])), " ")
+}
 
-	args := []string{"workflow", "run", wf.Filename}
-	if m.branch != "" {
-		args = append(args, "--ref", m.branch)
+// dispatchRepo returns the repository gh commands must name explicitly:
+// the target repository in remote mode, empty when running in a checkout.
+func (m Model) dispatchRepo() string {
+	if m.remote {
+		return m.repo
 	}
 
-	for _, name := range m.inputOrder {
-		val := m.inputs[name]
-		if val != "" {
-			args = append(args, "-f", name+"="+val)
-		}
+	return ""
+}
+
+// inputTypes maps each of wf's input names to its normalized input type.
+func inputTypes(wf workflow.File) map[string]string {
+	inputs := wf.GetInputs()
+
+	types := make(map[string]string, len(inputs))
+	for name, input := range inputs {
+		types[name] = input.InputType()
 	}
 
-	return "gh " + strings.Join(args, " ")
+	return types
+}
+
+// isNumber reports whether value is empty or a number, per workflow.IsNumber.
+func isNumber(value string) bool {
+	return value == "" || workflow.IsNumber(value)
 }
 
 func (m Model) watcherSubscription() tea.Cmd {
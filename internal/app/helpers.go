@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/kyleking/gh-lazydispatch/internal/expr"
 	"github.com/kyleking/gh-lazydispatch/internal/frecency"
 	"github.com/kyleking/gh-lazydispatch/internal/workflow"
 )
@@ -35,17 +36,88 @@ func (m *Model) initializeInputs(wf workflow.File) {
 	m.inputs = make(map[string]string)
 	m.inputOrder = nil
 
-	for name, input := range wf.GetInputs() {
-		m.inputs[name] = input.Default
-		m.inputOrder = append(m.inputOrder, name)
+	inputs := wf.GetInputs()
+
+	for _, name := range wf.InputNames() {
+		m.inputs[name] = inputs[name].Default
+
+		if !inputs[name].Hidden {
+			m.inputOrder = append(m.inputOrder, name)
+		}
 	}
 
-	sort.Strings(m.inputOrder)
 	m.filteredInputs = m.inputOrder
+	m.inputChanges = m.diffAgainstLocal(wf)
 	m.filterText = ""
 	m.selectedInput = -1
 	m.viewMode = WorkflowListMode
 	m.syncHistoryEntries()
+	m.syncPresets()
+}
+
+// diffAgainstLocal compares wf, loaded from another branch, with the local
+// copy of the same workflow. Returns nil when wf is the local copy.
+func (m Model) diffAgainstLocal(wf workflow.File) map[string]workflow.InputChange {
+	if m.workflowsRef == "" {
+		return nil
+	}
+
+	// wf already has the overrides applied, so the local copy needs them too.
+	for _, local := range m.localWorkflows {
+		if local.Filename == wf.Filename {
+			return workflow.DiffInputs(m.wfdConfig.OverrideWorkflow(local), wf)
+		}
+	}
+
+	return workflow.DiffInputs(workflow.File{}, wf)
+}
+
+// removedInputs returns the sorted names of local inputs missing on the branch.
+func (m Model) removedInputs() []string {
+	var removed []string
+
+	for name, change := range m.inputChanges {
+		if change == workflow.InputRemoved {
+			removed = append(removed, name)
+		}
+	}
+
+	sort.Strings(removed)
+
+	return removed
+}
+
+// expressionContext builds the context job conditions are evaluated against:
+// the current input values and what the github context holds for a dispatch
+// to the selected branch.
+func (m Model) expressionContext(wf workflow.File) expr.Context {
+	ctx := expr.Context{
+		Inputs: make(map[string]expr.Value),
+		Github: map[string]expr.Value{
+			"event_name": expr.String("workflow_dispatch"),
+			"repository": expr.String(m.repo),
+		},
+	}
+
+	if owner, _, ok := strings.Cut(m.repo, "/"); ok {
+		ctx.Github["repository_owner"] = expr.String(owner)
+	}
+
+	if m.branch != "" {
+		ctx.Github["ref"] = expr.String("refs/heads/" + m.branch)
+		ctx.Github["ref_name"] = expr.String(m.branch)
+		ctx.Github["ref_type"] = expr.String("branch")
+	}
+
+	for name, input := range wf.GetInputs() {
+		value := m.inputs[name]
+
+		// github.event.inputs holds strings, while inputs keeps booleans and numbers typed.
+		ctx.Github["event.inputs."+name] = expr.String(value)
+		ctx.Inputs[name] = _typedInputValue(input.InputType(), value)
+	}
+
+	return ctx
 }
 
 func (m *Model) syncHistoryEntries() {
@@ -71,6 +143,20 @@ func (m Model) getSelectedInputName() string {
 	return m.filteredInputs[m.selectedInput]
 }
 
+// _typedInputValue converts an input value to the type the inputs context gives it.
+func _typedInputValue(inputType, value string) expr.Value {
+	switch inputType {
+	case workflow.InputTypeBoolean:
+		return expr.Bool(value == boolTrueValue)
+	case workflow.InputTypeNumber:
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return expr.Number(n)
+		}
+	}
+
+	return expr.String(value)
+}
+
 func _padRight(s string, length int) string {
 	if len(s) >= length {
 		return s
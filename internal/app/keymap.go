@@ -22,6 +22,7 @@ type KeyMap struct {
 	LiveView key.Binding
 	Quit     key.Binding
 	Reset    key.Binding
+	Preset   key.Binding
 	ShiftTab key.Binding
 	Space    key.Binding
 	Tab      key.Binding
@@ -81,6 +82,7 @@ func DefaultKeyMap() KeyMap {
 		Help:     key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
 		LiveView: key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "live view")),
 		Quit:     key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+		Preset:   key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "save preset")),
 		Reset:    key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reset inputs")),
 		ShiftTab: key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "prev pane")),
 		Space:    key.NewBinding(key.WithKeys("space"), key.WithHelp("space", "select")),
@@ -141,7 +143,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 		{k.Tab, k.ShiftTab, k.Up, k.Down},
 		{k.TabNext, k.TabPrev, k.Clear, k.ClearAll},
 		{k.Enter, k.Edit, k.Escape, k.Branch},
-		{k.Watch, k.Filter, k.Copy, k.Reset},
+		{k.Watch, k.Filter, k.Copy, k.Reset, k.Preset},
 		{k.Input1, k.Input2, k.Input3, k.Input0},
 		{k.Quit, k.Help},
 	}
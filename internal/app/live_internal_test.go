@@ -82,8 +82,8 @@ func loadLiveEnv(t *testing.T) liveEnv {
 }
 
 // TestLiveDispatch drives the TUI headlessly against a throwaway repo and lets
-// the real dispatch path run: the same tea.ExecProcess call the binary makes,
-// shelling out to a real `gh workflow run`.
+// the real dispatch path run: the same REST dispatch and run lookup the binary
+// makes through `gh api`.
 func TestLiveDispatch(t *testing.T) {
 	env := loadLiveEnv(t)
 
@@ -91,11 +91,13 @@ func TestLiveDispatch(t *testing.T) {
 	t.Setenv("XDG_CACHE_HOME", t.TempDir())
 	t.Chdir(env.dir)
 
-	workflows, err := workflow.Discover(env.dir)
+	report, err := workflow.Discover(env.dir)
 	if err != nil {
 		t.Fatalf("discovering workflows in %s: %v", env.dir, err)
 	}
 
+	workflows := report.Dispatchable
+
 	assertScratchWorkflow(t, workflows, env.workflow)
 
 	want := map[string]string{
@@ -115,11 +117,11 @@ func TestLiveDispatch(t *testing.T) {
 
 	tm.Send(tea.KeyPressMsg{Code: 'y', Text: "y"})
 
-	// gh workflow run writes its success line to the program's stdout, which is
-	// the teatest buffer. Waiting on it also orders the quit key after the
-	// dispatch, which would otherwise race the confirmation command.
+	// The status bar names the workflow once the dispatch has returned its run.
+	// Waiting on it also orders the quit key after the dispatch, which would
+	// otherwise race the confirmation command.
 	teatest.WaitFor(t, tm.Output(), func(bts []byte) bool {
-		return bytes.Contains(bts, []byte("Created workflow_dispatch event"))
+		return bytes.Contains(bts, []byte("Dispatched "+env.workflow))
 	}, teatest.WithDuration(dispatchWaitTimeout))
 
 	tm.Send(tea.KeyPressMsg{Code: 'q', Text: "q"})
@@ -136,6 +138,8 @@ func TestLiveDispatch(t *testing.T) {
 	run := waitForDispatchedRun(t, env)
 	t.Logf("dispatched run %d: %s", run.DatabaseID, run.URL)
 
+	assertRunWatched(t, final, run)
+
 	writeResult(t, env, run, want)
 }
 
@@ -262,6 +266,20 @@ func assertHistoryRecorded(t *testing.T, m Model, env liveEnv, want map[string]s
 	}
 }
 
+// assertRunWatched checks that the run the TUI resolved and put in the Live
+// tab is the one the dispatch started.
+func assertRunWatched(t *testing.T, m Model, run ghRun) {
+	t.Helper()
+
+	if m.watcher == nil {
+		t.Fatal("no run watcher: the GitHub client was not created")
+	}
+
+	if _, watched := m.watcher.GetRun(run.DatabaseID); !watched {
+		t.Errorf("run %d is not in the Live tab; watching %+v", run.DatabaseID, m.watcher.GetRuns())
+	}
+}
+
 type ghRun struct {
 	Event      string `json:"event"`
 	HeadBranch string `json:"headBranch"`
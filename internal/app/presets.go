@@ -0,0 +1,152 @@
+package app
+
+import (
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/ui/modal"
+	"github.com/kyleking/gh-lazydispatch/internal/validation"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// syncPresets shows the selected workflow's presets, counting the values in
+// each that no longer fit its inputs.
+func (m *Model) syncPresets() {
+	wf := m.SelectedWorkflow()
+	if wf == nil {
+		m.rightPanel.SetPresets(nil, nil)
+		return
+	}
+
+	presets := m.wfdConfig.Presets(wf.Filename)
+	drift := make(map[string]int, len(presets))
+
+	for name, preset := range presets {
+		drift[name] = len(validation.ValidateInputs(preset.Inputs, wf, m.environmentNames()))
+	}
+
+	m.rightPanel.SetPresets(presets, drift)
+}
+
+// loadPreset fills the config pane with a preset's values. When some of them
+// no longer fit the workflow, the remap wizard runs first and the preset is
+// loaded with its decisions applied.
+func (m Model) loadPreset(preset config.Preset) (tea.Model, tea.Cmd) {
+	wf := m.SelectedWorkflow()
+	if wf == nil {
+		return m, nil
+	}
+
+	if errs := validation.ValidateInputs(preset.Inputs, wf, m.environmentNames()); len(errs) > 0 {
+		m.pendingPreset = &preset
+		m.modalStack.Push(modal.NewRemapModal(errs, wf.GetInputs()))
+
+		return m, nil
+	}
+
+	return m.applyPreset(preset)
+}
+
+// applyPreset fills the inputs from a preset's values. A preset that pins
+// another branch switches to it, reloading the workflows there.
+func (m Model) applyPreset(preset config.Preset) (tea.Model, tea.Cmd) {
+	wf := m.SelectedWorkflow()
+	if wf == nil {
+		return m, nil
+	}
+
+	m.fillInputs(*wf, preset.Inputs)
+
+	m.focused = PaneConfig
+	m.viewMode = WorkflowListMode
+	m.previewingHistoryEntry = nil
+
+	if preset.Branch == "" || preset.Branch == m.branch {
+		return m, nil
+	}
+
+	m.branch = preset.Branch
+
+	return m, m.loadWorkflowsAtBranch(preset.Branch)
+}
+
+// fillInputs resets the inputs to wf's defaults, then sets values on top,
+// except on locked and hidden inputs, which keep what lazydispatch.yml gives
+// them. Values for inputs wf does not declare are kept so validation can
+// report them.
+func (m *Model) fillInputs(wf workflow.File, values map[string]string) {
+	inputs := wf.GetInputs()
+	m.inputs = make(map[string]string, len(inputs))
+
+	for name, input := range inputs {
+		m.inputs[name] = input.Default
+	}
+
+	for name, value := range values {
+		if input, declared := inputs[name]; !declared || (!input.Locked && !input.Hidden) {
+			m.inputs[name] = value
+		}
+	}
+}
+
+//nolint:unparam // consistent (tea.Model, tea.Cmd) handler signature per Update's dispatch convention
+func (m Model) openSavePresetModal() (tea.Model, tea.Cmd) {
+	wf := m.SelectedWorkflow()
+	if wf == nil {
+		return m, nil
+	}
+
+	if m.remote {
+		m.modalStack.Push(modal.NewErrorModal("Cannot Save Preset",
+			"Presets are written to "+config.ConfigFilename+" in a local checkout."))
+
+		return m, nil
+	}
+
+	m.pendingInputName = ""
+	m.pendingPresetSave = true
+	m.modalStack.Push(modal.NewInputModal(
+		"Save preset for "+wf.Filename,
+		"Saved to "+config.ConfigFilename+", replacing any preset with the same name. "+
+			"Inputs left at their default are not saved; the branch is.",
+		"", workflow.InputTypeString, "", nil,
+		[]rule.ValidationRule{{Type: rule.RuleRequired}},
+	))
+
+	return m, nil
+}
+
+// savePreset writes the inputs that differ from their defaults, and the
+// current branch, as a preset named name, then reloads the config so it is listed straight away.
+func (m Model) savePreset(name string) (tea.Model, tea.Cmd) {
+	wf := m.SelectedWorkflow()
+	if wf == nil {
+		return m, nil
+	}
+
+	values := make(map[string]string)
+
+	for inputName, input := range wf.GetInputs() {
+		if value, ok := m.inputs[inputName]; ok && value != input.Default && !input.Locked {
+			values[inputName] = value
+		}
+	}
+
+	root := m.configRoot()
+
+	err := config.SavePreset(filepath.Join(root, config.ConfigFilename), wf.Filename, name, config.Preset{
+		Inputs: values,
+		Branch: m.branch,
+	})
+	if err != nil {
+		m.modalStack.Push(modal.NewErrorModal("Failed to Save Preset", err.Error()))
+		return m, nil
+	}
+
+	m.reloadConfig(root)
+
+	return m, m.showNotice("Saved preset " + name)
+}
@@ -0,0 +1,203 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/reload"
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// reloadNoticeDuration is how long the "reloaded" notice stays in the status bar.
+const reloadNoticeDuration = 4 * time.Second
+
+// filesChangedMsg carries the workflow and config files edited since the last poll.
+type filesChangedMsg struct {
+	Paths []string
+}
+
+// reloadNoticeExpiredMsg clears the reload notice it was scheduled for,
+// unless a newer reload has replaced it.
+type reloadNoticeExpiredMsg struct {
+	seq int
+}
+
+// WithReload watches the workflow files and lazydispatch.yml under root, and
+// the values files of their oneof-file rules, re-parsing them whenever they
+// change while the TUI runs.
+func (m Model) WithReload(root string) Model {
+	m.fileWatcher = reload.NewWatcher(root)
+	m.fileWatcher.Watch(m.valuesFiles())
+
+	return m
+}
+
+// valuesFiles returns the values files of the oneof-file rules on the local
+// workflows and in lazydispatch.yml, sorted.
+func (m Model) valuesFiles() []string {
+	var paths []string
+
+	add := func(rules []rule.ValidationRule) {
+		for _, r := range rules {
+			if r.Type == rule.RuleOneOfFile && !slices.Contains(paths, r.Pattern) {
+				paths = append(paths, r.Pattern)
+			}
+		}
+	}
+
+	for _, wf := range m.localWorkflows {
+		for _, input := range wf.GetInputs() {
+			add(input.ValidationRules)
+		}
+	}
+
+	if m.wfdConfig != nil {
+		for _, override := range m.wfdConfig.Workflows {
+			for _, input := range override.Inputs {
+				add(input.Rules)
+			}
+		}
+	}
+
+	slices.Sort(paths)
+
+	return paths
+}
+
+// pollFiles schedules the next filesystem scan, or returns nil when reloading is off.
+func (m Model) pollFiles() tea.Cmd {
+	if m.fileWatcher == nil {
+		return nil
+	}
+
+	w := m.fileWatcher
+
+	return tea.Tick(reload.PollInterval, func(time.Time) tea.Msg {
+		return filesChangedMsg{Paths: w.Poll()}
+	})
+}
+
+// handleFilesChanged re-parses edited workflow and config files and shows a
+// transient notice with the number of files reloaded and errors found in them.
+// An edited values file reloads both, since either may have rules reading it.
+func (m Model) handleFilesChanged(msg filesChangedMsg) (tea.Model, tea.Cmd) {
+	if len(msg.Paths) == 0 || m.fileWatcher == nil {
+		return m, m.pollFiles()
+	}
+
+	root := m.fileWatcher.Root()
+	workflowsChanged, configChanged := false, false
+
+	for _, path := range msg.Paths {
+		switch {
+		case reload.IsConfigPath(path):
+			configChanged = true
+		case reload.IsWorkflowPath(path):
+			workflowsChanged = true
+		default:
+			configChanged, workflowsChanged = true, true
+		}
+	}
+
+	errCount := 0
+
+	if configChanged && !m.reloadConfig(root) {
+		errCount++
+	}
+
+	if workflowsChanged {
+		report, err := workflow.Discover(root)
+		if err != nil {
+			errCount++
+		} else {
+			errCount += countFailuresIn(report, msg.Paths)
+			m = m.reloadWorkflows(report)
+		}
+	}
+
+	m.fileWatcher.Watch(m.valuesFiles())
+
+	notice := m.showNotice(fmt.Sprintf("Reloaded %d file(s) / %d error(s)", len(msg.Paths), errCount))
+
+	return m, tea.Batch(m.pollFiles(), notice)
+}
+
+// showNotice puts text in the status bar and returns the command that clears
+// it after reloadNoticeDuration, unless a newer notice replaced it.
+func (m *Model) showNotice(text string) tea.Cmd {
+	m.reloadNoticeSeq++
+	m.reloadNotice = text
+	seq := m.reloadNoticeSeq
+
+	return tea.Tick(reloadNoticeDuration, func(time.Time) tea.Msg {
+		return reloadNoticeExpiredMsg{seq: seq}
+	})
+}
+
+// configRoot returns the directory lazydispatch.yml is read from.
+func (m Model) configRoot() string {
+	if m.fileWatcher != nil {
+		return m.fileWatcher.Root()
+	}
+
+	return "."
+}
+
+// reloadConfig re-reads lazydispatch.yml and re-applies its input overrides,
+// returning false if it failed to parse. A deleted config clears the chains
+// and overrides; a broken one keeps the previous ones.
+func (m *Model) reloadConfig(root string) bool {
+	cfg, err := config.Load(root)
+	if err != nil && !errors.Is(err, config.ErrConfigNotFound) {
+		return false
+	}
+
+	m.wfdConfig = cfg
+
+	var chains map[string]config.Chain
+	if cfg != nil {
+		chains = cfg.Chains
+	}
+
+	m.rightPanel.SetChains(chains)
+	*m = m.applyWorkflows(m.baseWorkflows, m.workflowsRef)
+
+	return true
+}
+
+// reloadWorkflows replaces the local workflows with a fresh discovery. While
+// another branch is shown, only the comparison against the local copy changes.
+func (m Model) reloadWorkflows(report *workflow.DiscoveryReport) Model {
+	m.discoveryReport = report
+	m.localWorkflows = report.Dispatchable
+
+	if m.workflowsRef == "" {
+		return m.applyWorkflows(report.Dispatchable, "")
+	}
+
+	if wf := m.SelectedWorkflow(); wf != nil {
+		m.inputChanges = m.diffAgainstLocal(*wf)
+	}
+
+	return m
+}
+
+// countFailuresIn counts the report's parse failures located in paths.
+func countFailuresIn(report *workflow.DiscoveryReport, paths []string) int {
+	count := 0
+
+	for _, failure := range report.Failures {
+		if slices.Contains(paths, filepath.ToSlash(failure.Path)) {
+			count++
+		}
+	}
+
+	return count
+}
@@ -1,12 +1,16 @@
 package app
 
 import (
+	"slices"
 	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/x/ansi"
 	"github.com/charmbracelet/x/exp/golden"
+
+	"github.com/kyleking/gh-lazydispatch/internal/exec"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
 )
 
 func newRenderModel() Model {
@@ -35,8 +39,8 @@ func applyMsg(t *testing.T, m Model, msg tea.Msg) Model {
 
 // drainCmd executes returned commands and feeds resulting messages back into
 // Update so modal result messages reach their handlers, mirroring the runtime
-// message loop. Commands are invoked directly, which constructs messages
-// without side effects (tea.ExecProcess only builds its message lazily).
+// message loop. Commands are invoked directly, so any GitHub calls they make
+// must go to a mock executor.
 func drainCmd(t *testing.T, m Model, cmd tea.Cmd) Model {
 	t.Helper()
 
@@ -198,7 +202,16 @@ func TestHelpModalTinyTerminal(t *testing.T) {
 func TestDispatchFlowEndState(t *testing.T) {
 	t.Setenv("XDG_CACHE_HOME", t.TempDir())
 
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddGHWorkflowDispatch("owner", "repo", "deploy.yml", 42)
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
 	m := resize(t, newRenderModel(), 120, 40)
+	m.ghClient = client
 	m.focused = PaneConfig
 
 	m = pressRune(t, m, '0')
@@ -226,6 +239,12 @@ func TestDispatchFlowEndState(t *testing.T) {
 		t.Fatal("expected run confirm modal to close after confirming")
 	}
 
+	if !slices.ContainsFunc(mockExec.ExecutedCommands, func(cmd exec.ExecutedCommand) bool {
+		return slices.Contains(cmd.Args, "repos/owner/repo/actions/workflows/deploy.yml/dispatches")
+	}) {
+		t.Error("expected the workflow to be dispatched through the API")
+	}
+
 	entries := m.history.TopForRepo("owner/repo", "deploy.yml", 1)
 	if len(entries) == 0 {
 		t.Fatal("expected dispatch to be recorded in history")
@@ -2,12 +2,14 @@ package app
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
 	"github.com/kyleking/gh-lazydispatch/internal/chain"
+	"github.com/kyleking/gh-lazydispatch/internal/config"
 	"github.com/kyleking/gh-lazydispatch/internal/ui"
 	"github.com/kyleking/gh-lazydispatch/internal/ui/panes"
 	"github.com/kyleking/gh-lazydispatch/internal/validation"
@@ -103,6 +105,10 @@ func (m Model) viewTopStatusBar() string {
 		parts = append(parts, fmt.Sprintf("Chains(%d)", len(m.wfdConfig.Chains)))
 	}
 
+	if m.discoveryReport.HasFailures() {
+		parts = append(parts, fmt.Sprintf("Errors(%d)", len(m.discoveryReport.Failures)))
+	}
+
 	if m.watcher != nil {
 		runs := m.watcher.GetRuns()
 		if len(runs) > 0 {
@@ -125,6 +131,10 @@ func (m Model) viewTopStatusBar() string {
 		}
 	}
 
+	if m.reloadNotice != "" {
+		parts = append(parts, m.reloadNotice)
+	}
+
 	left := strings.Join(parts, "  ")
 	right := "lazydispatch"
 
@@ -152,9 +162,11 @@ func (m Model) viewFooterBar() string {
 			hints = append(hints, "[h/l] tab", "[j/k] select", "[Enter] run chain")
 		case panes.TabLive:
 			hints = append(hints, "[h/l] tab", "[j/k] select", "[d] clear", "[D] clear all")
+		case panes.TabPresets:
+			hints = append(hints, "[h/l] tab", "[j/k] select", "[Enter] load")
 		}
 	case PaneConfig:
-		hints = append(hints, "[Enter] run", "[1-0] edit", "[/] filter", "[b] branch")
+		hints = append(hints, "[Enter] run", "[1-0] edit", "[/] filter", "[b] branch", "[S] save preset")
 	}
 
 	hints = append(hints, "[?] help", "[q] quit")
@@ -187,26 +199,43 @@ func (m Model) viewInputDetailsPane(width, height int) string {
 	content.WriteString(ui.TitleStyle.Render(m.leftPaneTitle()))
 	content.WriteString("\n\n")
 
-	_renderInputHeader(&content, selectedName, input.Required)
+	_renderInputHeader(&content, selectedName, input)
 	_renderInputType(&content, input.InputType())
 	_renderInputOptions(&content, input.InputType(), input.Options)
+	_renderOptionsProvider(&content, input.OptionsProvider)
 	_renderInputDescription(&content, input.Description, width)
 	_renderInputValues(&content, m.inputs[selectedName], input.Default)
+	_renderWorkflowSummary(&content, *wf, width)
+
+	help := "[Esc] back  [e] edit"
+	if input.Locked {
+		help = "[Esc] back  (locked in " + config.ConfigFilename + ")"
+	}
 
 	content.WriteString("\n\n")
-	content.WriteString(ui.HelpStyle.Render("[Esc] back  [e] edit"))
+	content.WriteString(ui.HelpStyle.Render(help))
 
 	return style.Render(content.String())
 }
 
-func _renderInputHeader(content *strings.Builder, name string, required bool) {
-	content.WriteString(ui.TitleStyle.Render(name))
+func _renderInputHeader(content *strings.Builder, name string, input workflow.Input) {
+	content.WriteString(ui.TitleStyle.Render(input.DisplayName(name)))
+
+	if input.Label != "" {
+		content.WriteString(" ")
+		content.WriteString(ui.HelpStyle.Render("(" + name + ")"))
+	}
 
-	if required {
+	if input.Required {
 		content.WriteString(" ")
 		content.WriteString(ui.SelectedStyle.Render("(required)"))
 	}
 
+	if input.Locked {
+		content.WriteString(" ")
+		content.WriteString(ui.SelectedStyle.Render("(locked)"))
+	}
+
 	content.WriteString("\n\n")
 }
 
@@ -216,6 +245,18 @@ func _renderInputType(content *strings.Builder, inputType string) {
 	content.WriteString("\n")
 }
 
+func _renderOptionsProvider(content *strings.Builder, provider *workflow.OptionsProvider) {
+	if provider == nil {
+		return
+	}
+
+	content.WriteString(ui.SubtitleStyle.Render("Options: "))
+	content.WriteString(ui.NormalStyle.Render(provider.Describe()))
+	content.WriteString(" ")
+	content.WriteString(ui.HelpStyle.Render("(listed when edited)"))
+	content.WriteString("\n")
+}
+
 func _renderInputOptions(content *strings.Builder, inputType string, options []string) {
 	if inputType != inputTypeChoice || len(options) == 0 {
 		return
@@ -246,6 +287,124 @@ func _renderInputDescription(content *strings.Builder, description string, width
 	content.WriteString("\n")
 }
 
+// _renderWorkflowSummary lists the workflow's jobs, the environments it
+// deploys to, and whether dispatching cancels in-progress runs.
+func _renderWorkflowSummary(content *strings.Builder, wf workflow.File, width int) {
+	if len(wf.Jobs) == 0 && wf.Concurrency == nil && wf.Permissions == nil {
+		return
+	}
+
+	maxLineWidth := width - paneContentMargin
+
+	content.WriteString("\n\n")
+	content.WriteString(ui.SubtitleStyle.Render("Workflow:"))
+
+	for _, job := range wf.Jobs {
+		line := "  - " + job.DisplayName()
+		if len(job.Needs) > 0 {
+			line += " (after " + strings.Join(job.Needs, ", ") + ")"
+		}
+
+		if job.Environment.Name != "" {
+			line += " → " + job.Environment.Name
+		}
+
+		content.WriteString("\n")
+		content.WriteString(ui.NormalStyle.Render(ui.TruncateWithEllipsis(line, maxLineWidth)))
+	}
+
+	if envs := wf.Jobs.Environments(); len(envs) > 0 {
+		content.WriteString("\n")
+		content.WriteString(ui.SubtitleStyle.Render("Deploys to: "))
+		content.WriteString(ui.NormalStyle.Render(strings.Join(envs, ", ")))
+	}
+
+	if note := _concurrencyNote(wf); note != "" {
+		content.WriteString("\n")
+		content.WriteString(ui.SubtitleStyle.Render("Concurrency: "))
+		content.WriteString(ui.NormalStyle.Render(_wordWrap(note, maxLineWidth)))
+	}
+
+	if wf.Permissions != nil {
+		content.WriteString("\n")
+		content.WriteString(ui.SubtitleStyle.Render("Permissions: "))
+		content.WriteString(ui.NormalStyle.Render(_wordWrap(wf.Permissions.String(), maxLineWidth)))
+	}
+
+	if len(wf.Calls) > 0 {
+		content.WriteString("\n")
+		content.WriteString(ui.SubtitleStyle.Render("Calls:"))
+		_renderCallTree(content, wf.Calls, 1, maxLineWidth)
+	}
+
+	if notPassed := wf.InputsNotPassed(); len(notPassed) > 0 {
+		content.WriteString("\n")
+		content.WriteString(ui.SubtitleStyle.Render(_wordWrap(
+			"Not passed to any called workflow: "+strings.Join(notPassed, ", "), maxLineWidth,
+		)))
+	}
+}
+
+// _renderCallTree lists reusable workflow calls with the with: values they
+// pass, indenting each nested level.
+func _renderCallTree(content *strings.Builder, calls []workflow.ReusableCall, depth, maxLineWidth int) {
+	indent := strings.Repeat("  ", depth)
+
+	for _, call := range calls {
+		line := indent + call.JobID + " → " + call.Name
+		if !call.Resolved {
+			line += " (not resolved)"
+		}
+
+		content.WriteString("\n")
+		content.WriteString(ui.NormalStyle.Render(ui.TruncateWithEllipsis(line, maxLineWidth)))
+
+		keys := make([]string, 0, len(call.With))
+		for key := range call.With {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			content.WriteString("\n")
+			content.WriteString(ui.HelpStyle.Render(
+				ui.TruncateWithEllipsis(indent+"  "+key+": "+call.With[key], maxLineWidth),
+			))
+		}
+
+		_renderCallTree(content, call.Calls, depth+1, maxLineWidth)
+	}
+}
+
+// _concurrencyNote describes whether a dispatch cancels in-progress runs,
+// through the workflow's concurrency group or any job's.
+func _concurrencyNote(wf workflow.File) string {
+	switch {
+	case wf.Concurrency.CancelsInProgress():
+		return "cancels in-progress runs of " + wf.Concurrency.Group
+	case wf.Concurrency.MayCancelInProgress():
+		return "may cancel in-progress runs of " + wf.Concurrency.Group
+	}
+
+	var cancelling []string
+
+	for _, job := range wf.Jobs {
+		if job.Concurrency.CancelsInProgress() || job.Concurrency.MayCancelInProgress() {
+			cancelling = append(cancelling, job.DisplayName())
+		}
+	}
+
+	switch {
+	case len(cancelling) > 0:
+		return "jobs " + strings.Join(cancelling, ", ") + " may cancel in-progress runs"
+	case wf.Concurrency != nil:
+		return "queues behind " + wf.Concurrency.Group
+	}
+
+	return ""
+}
+
 func _renderInputValues(content *strings.Builder, current, defaultVal string) {
 	content.WriteString("\n")
 	content.WriteString(ui.SubtitleStyle.Render("Current: "))
@@ -273,6 +432,10 @@ func (m Model) viewWorkflowPane(width, height int) string {
 	title := ui.TitleStyle.Render(m.leftPaneTitle())
 	maxLineWidth := width - paneContentMargin
 
+	if len(m.workflows) == 0 {
+		return style.Render(title + "\n" + m.viewDiscoveryReport(maxLineWidth))
+	}
+
 	var content strings.Builder
 
 	allLine := "all"
@@ -311,6 +474,42 @@ func (m Model) viewWorkflowPane(width, height int) string {
 	return style.Render(title + "\n" + content.String())
 }
 
+// viewDiscoveryReport renders the workflow pane's empty state: why no workflow
+// is dispatchable and which files failed to parse.
+func (m Model) viewDiscoveryReport(maxLineWidth int) string {
+	var content strings.Builder
+
+	content.WriteString(ui.SubtitleStyle.Render("No dispatchable workflows"))
+
+	report := m.discoveryReport
+	if report == nil {
+		return content.String()
+	}
+
+	if len(report.NonDispatchable) > 0 {
+		content.WriteString("\n")
+		content.WriteString(ui.NormalStyle.Render(
+			fmt.Sprintf("%d without workflow_dispatch", len(report.NonDispatchable)),
+		))
+	}
+
+	if !report.HasFailures() {
+		return content.String()
+	}
+
+	content.WriteString("\n\n")
+	content.WriteString(ui.ErrorTitleStyle.Render(fmt.Sprintf("Failed to parse (%d):", len(report.Failures))))
+
+	for _, failure := range report.Failures {
+		content.WriteString("\n")
+		content.WriteString(ui.ErrorStyle.Render(ui.TruncateWithEllipsis(failure.Location(), maxLineWidth)))
+		content.WriteString("\n")
+		content.WriteString(ui.NormalStyle.Render(_wordWrap(failure.Err.Error(), maxLineWidth)))
+	}
+
+	return content.String()
+}
+
 func (m Model) viewHistoryConfigPane(width, height int) string {
 	style := ui.PaneStyle(width, height, m.focused == PaneWorkflows)
 
@@ -337,15 +536,22 @@ func (m Model) viewHistoryConfigPane(width, height int) string {
 
 	var validationErrors []validation.ConfigValidationError
 	if currentWorkflow != nil {
-		validationErrors = validation.ValidateHistoryConfig(entry, currentWorkflow)
+		validationErrors = validation.ValidateHistoryConfigWithEnvironments(entry, currentWorkflow, m.environmentNames())
 	}
 
 	errorMap := make(map[string]validation.ConfigValidationError)
+
+	var missingRequired []string
+
 	for _, err := range validationErrors {
 		errorMap[err.HistoricalName] = err
+
+		if _, recorded := entry.Inputs[err.HistoricalName]; !recorded && err.Status == validation.StatusRequiredMissing {
+			missingRequired = append(missingRequired, err.HistoricalName)
+		}
 	}
 
-	if len(entry.Inputs) == 0 {
+	if len(entry.Inputs) == 0 && len(missingRequired) == 0 {
 		content.WriteString(ui.SubtitleStyle.Render("No inputs"))
 	} else {
 		content.WriteString(ui.SubtitleStyle.Render("Inputs:"))
@@ -371,6 +577,12 @@ func (m Model) viewHistoryConfigPane(width, height int) string {
 					content.WriteString(ui.SubtitleStyle.Render("type changed"))
 				case validation.StatusOptionsChanged:
 					content.WriteString(ui.SubtitleStyle.Render("invalid option"))
+				case validation.StatusEnvironmentMissing:
+					content.WriteString(ui.SubtitleStyle.Render("environment deleted"))
+				case validation.StatusRequiredMissing:
+					content.WriteString(ui.SubtitleStyle.Render("required"))
+				case validation.StatusRuleFailed:
+					content.WriteString(ui.SubtitleStyle.Render("fails validation"))
 				}
 
 				content.WriteString(ui.SubtitleStyle.Render(")"))
@@ -382,6 +594,17 @@ func (m Model) viewHistoryConfigPane(width, height int) string {
 
 			content.WriteString("\n")
 		}
+
+		for _, name := range missingRequired {
+			content.WriteString("  ")
+			content.WriteString(ui.TableItalicStyle.Render("! "))
+			content.WriteString(ui.TableDefaultStyle.Render(name))
+			content.WriteString(": ")
+			content.WriteString(ui.TableDefaultStyle.Render(ui.FormatEmptyValue("")))
+			content.WriteString(" ")
+			content.WriteString(ui.SubtitleStyle.Render("(required)"))
+			content.WriteString("\n")
+		}
 	}
 
 	content.WriteString("\n")
@@ -395,6 +618,19 @@ func (m Model) viewHistoryConfigPane(width, height int) string {
 	return style.Render(content.String())
 }
 
+// staleRefNote warns that the workflows shown were read from a git ref that
+// may not match what GitHub will dispatch, or is empty when they were not.
+func (m Model) staleRefNote() string {
+	switch {
+	case m.workflowsRef == "" || m.staleRef == "":
+		return ""
+	case m.staleRef == m.workflowsRef:
+		return "Workflows from local branch " + m.staleRef + ", which may not match GitHub"
+	default:
+		return "Workflows from " + m.staleRef + " as of the last git fetch"
+	}
+}
+
 func (m Model) viewConfigPane(width, height int) string {
 	style := ui.PaneStyle(width, height, m.focused == PaneConfig)
 
@@ -428,6 +664,11 @@ func (m Model) viewConfigPane(width, height int) string {
 	content.WriteString("    [r] reset all")
 	content.WriteString("\n")
 
+	if note := m.staleRefNote(); note != "" {
+		content.WriteString(ui.SubtitleStyle.Render(note))
+		content.WriteString("\n")
+	}
+
 	if m.filterText != "" {
 		content.WriteString(ui.SubtitleStyle.Render("Filter: /" + m.filterText))
 		content.WriteString("\n")
@@ -438,6 +679,18 @@ func (m Model) viewConfigPane(width, height int) string {
 	content.WriteString("\n")
 	content.WriteString(m.renderTableRows(height))
 
+	if removed := m.removedInputs(); len(removed) > 0 {
+		content.WriteString("\n")
+		content.WriteString(ui.SubtitleStyle.Render(
+			"Removed on " + m.workflowsRef + ": " + strings.Join(removed, ", "),
+		))
+	}
+
+	if preview := m.viewJobPreview(m.workflows[m.selectedWorkflow], width); preview != "" {
+		content.WriteString("\n")
+		content.WriteString(preview)
+	}
+
 	content.WriteString("\n\n")
 	content.WriteString(ui.SubtitleStyle.Render("Command ([c] copy):"))
 	content.WriteString("\n")
@@ -454,6 +707,27 @@ func (m Model) viewConfigPane(width, height int) string {
 	return style.Render(content.String())
 }
 
+// viewJobPreview lists the workflow's jobs, marking those the current inputs
+// and branch would skip or that cannot be predicted.
+func (m Model) viewJobPreview(wf workflow.File, width int) string {
+	if len(wf.Jobs) == 0 {
+		return ""
+	}
+
+	jobs := make([]string, 0, len(wf.Jobs))
+
+	for _, prediction := range wf.Jobs.Predict(m.expressionContext(wf)) {
+		name := prediction.Job.DisplayName()
+		if prediction.Outcome != workflow.JobRuns {
+			name += " (" + prediction.Outcome.String() + ")"
+		}
+
+		jobs = append(jobs, name)
+	}
+
+	return ui.SubtitleStyle.Render(ui.TruncateWithEllipsis("Jobs: "+strings.Join(jobs, ", "), width-cliPreviewMargin))
+}
+
 func (Model) renderTableHeader() string {
 	return ui.TableHeaderStyle.Render(
 		"  #   Req  Name             Value              Default",
@@ -505,7 +779,7 @@ func (m Model) renderTableRows(height int) string {
 		isSelected := i == m.selectedInput
 		isDimmed := val == input.Default
 
-		displayName := ui.TruncateWithEllipsis(name, inputNameColWidth)
+		displayName := ui.TruncateWithEllipsis(input.DisplayName(name), inputNameColWidth)
 		valueDisplay = ui.TruncateWithEllipsis(valueDisplay, inputValueColWidth)
 		defaultDisplay = ui.TruncateWithEllipsis(defaultDisplay, inputDefaultColWidth)
 
@@ -519,6 +793,14 @@ func (m Model) renderTableRows(height int) string {
 			_padRight(valueDisplay, inputValueColWidth) + "  " +
 			defaultDisplay
 
+		if input.Locked {
+			row += "  [locked]"
+		}
+
+		if change := m.inputChanges[name]; change != workflow.InputUnchanged {
+			row += "  [" + change.String() + " on " + m.workflowsRef + "]"
+		}
+
 		rowStyle := ui.TableRowStyle
 
 		switch {
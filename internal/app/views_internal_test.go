@@ -1,6 +1,16 @@
 package app
 
-import "testing"
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+var errBrokenWorkflow = errors.New("mapping values are not allowed in this context")
 
 // The "all workflows" row sets selectedWorkflow to -1 while viewMode and
 // filteredInputs can still hold a prior workflow's input selection, so View
@@ -33,3 +43,170 @@ func TestViewWithNoWorkflowSelected(t *testing.T) {
 		})
 	}
 }
+
+func TestViewWorkflowPane_RendersDiscoveryFailures(t *testing.T) {
+	t.Parallel()
+
+	m := New(nil, testHistory(), "owner/repo").WithDiscoveryReport(&workflow.DiscoveryReport{
+		Failures: []workflow.ParseError{{
+			Path: ".github/workflows/broken.yml",
+			Line: 4,
+			Err:  errBrokenWorkflow,
+		}},
+	})
+	m.width, m.height = 120, 40
+
+	pane := ansi.Strip(m.viewWorkflowPane(60, 20))
+
+	for _, want := range []string{"No dispatchable workflows", ".github/workflows/broken.yml:4", "mapping"} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("workflow pane missing %q:\n%s", want, pane)
+		}
+	}
+
+	if status := ansi.Strip(m.viewTopStatusBar()); !strings.Contains(status, "Errors(1)") {
+		t.Errorf("status bar missing error count: %q", status)
+	}
+}
+
+func TestViewInputDetailsPane_RendersWorkflowSummary(t *testing.T) {
+	t.Parallel()
+
+	wfs := testWorkflows()
+	wfs[0].Concurrency = &workflow.Concurrency{Group: "deploy", CancelInProgress: "true"}
+	wfs[0].Permissions = &workflow.Permissions{Scopes: map[string]string{"deployments": "write"}}
+	wfs[0].Jobs = workflow.Jobs{
+		{ID: "build"},
+		{ID: "release", Needs: workflow.StringList{"build"}, Environment: workflow.JobEnvironment{Name: "production"}},
+	}
+
+	m := New(wfs, testHistory(), "owner/repo")
+	m.width, m.height = 120, 40
+	m.initializeInputs(m.workflows[0])
+	m.selectedInput = 0
+	m.viewMode = InputDetailMode
+
+	pane := ansi.Strip(m.viewInputDetailsPane(80, 40))
+
+	for _, want := range []string{
+		"- build",
+		"- release (after build) → production",
+		"Deploys to: production",
+		"cancels in-progress runs of deploy",
+		"Permissions: deployments: write",
+	} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("details pane missing %q:\n%s", want, pane)
+		}
+	}
+}
+
+func TestConcurrencyNote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		wf   workflow.File
+		want string
+	}{
+		{name: "none", wf: workflow.File{}, want: ""},
+		{
+			name: "queues",
+			wf:   workflow.File{Concurrency: &workflow.Concurrency{Group: "deploy"}},
+			want: "queues behind deploy",
+		},
+		{
+			name: "expression",
+			wf: workflow.File{Concurrency: &workflow.Concurrency{
+				Group: "deploy", CancelInProgress: "${{ github.event_name == 'push' }}",
+			}},
+			want: "may cancel in-progress runs of deploy",
+		},
+		{
+			name: "job level",
+			wf: workflow.File{Jobs: workflow.Jobs{
+				{ID: "build"},
+				{ID: "deploy", Concurrency: &workflow.Concurrency{Group: "d", CancelInProgress: "true"}},
+			}},
+			want: "jobs deploy may cancel in-progress runs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := _concurrencyNote(tt.wf); got != tt.want {
+				t.Errorf("_concurrencyNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewConfigPane_PreviewsJobs(t *testing.T) {
+	t.Parallel()
+
+	wfs := testWorkflows()
+	wfs[0].Jobs = workflow.Jobs{
+		{ID: "build"},
+		{ID: "deploy", If: "${{ inputs.environment == 'production' }}"},
+		{ID: "release", If: "startsWith(github.ref_name, 'release/')"},
+		{ID: "publish", If: "secrets.TOKEN != ''"},
+	}
+
+	m := New(wfs, testHistory(), "owner/repo")
+	m.width, m.height = 160, 40
+	m.branch = "release/1.0"
+	m.initializeInputs(m.workflows[0])
+
+	want := "Jobs: build, deploy (skipped), release, publish (unknown)"
+	if pane := ansi.Strip(m.viewConfigPane(120, 30)); !strings.Contains(pane, want) {
+		t.Errorf("config pane missing %q:\n%s", want, pane)
+	}
+
+	m.inputs[testInputEnvironment] = "production"
+
+	want = "Jobs: build, deploy, release, publish (unknown)"
+	if pane := ansi.Strip(m.viewConfigPane(120, 30)); !strings.Contains(pane, want) {
+		t.Errorf("config pane missing %q:\n%s", want, pane)
+	}
+}
+
+func TestViewInputDetailsPane_RendersCallTree(t *testing.T) {
+	t.Parallel()
+
+	wfs := testWorkflows()
+	wfs[0].Jobs = workflow.Jobs{{ID: "deploy", Uses: "./.github/workflows/deploy.yml"}}
+	wfs[0].Calls = []workflow.ReusableCall{{
+		JobID:    "deploy",
+		Uses:     "./.github/workflows/deploy.yml",
+		Name:     "Reusable deploy",
+		Resolved: true,
+		With:     map[string]string{"region": "us-east-1"},
+		Calls: []workflow.ReusableCall{{
+			JobID: "lint",
+			Uses:  "octo-org/shared/.github/workflows/lint.yml@v1",
+			Name:  "octo-org/shared/.github/workflows/lint.yml@v1",
+		}},
+	}}
+
+	m := New(wfs, testHistory(), "owner/repo")
+	m.width, m.height = 160, 40
+	m.initializeInputs(m.workflows[0])
+	m.selectedInput = 0
+	m.viewMode = InputDetailMode
+
+	pane := ansi.Strip(m.viewInputDetailsPane(120, 40))
+
+	for _, want := range []string{
+		"Calls:",
+		"deploy → Reusable deploy",
+		"region: us-east-1",
+		"lint → octo-org/shared/.github/workflows/lint.yml@v1 (not resolved)",
+		"Not passed to any called workflow: " + testInputEnvironment,
+	} {
+		if !strings.Contains(pane, want) {
+			t.Errorf("details pane missing %q:\n%s", want, pane)
+		}
+	}
+}
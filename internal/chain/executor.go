@@ -230,8 +230,9 @@ func (e *ChainExecutor) runChain() {
 		result, err := e.runStep(i, step)
 		if err != nil {
 			e.handleStepError(i, step, err)
+			e.sendUpdate()
 
-			if e.state.Status == ChainFailed {
+			if e.State().Status == ChainFailed {
 				return
 			}
 
@@ -246,6 +247,7 @@ func (e *ChainExecutor) runChain() {
 
 		if result.Status == StepFailed {
 			if !e.handleStepFailure(i, step) {
+				e.sendUpdate()
 				return
 			}
 		}
@@ -281,7 +283,7 @@ func (e *ChainExecutor) runStep(idx int, step config.ChainStep) (*StepResult, er
 		Inputs:   inputs,
 	}
 
-	runID, err := runner.ExecuteAndGetRunID(cfg, e.client)
+	dispatched, err := runner.Dispatch(cfg, e.client)
 	if err != nil {
 		suggestion := ""
 		if e.branch != "" {
@@ -298,18 +300,26 @@ func (e *ChainExecutor) runStep(idx int, step config.ChainStep) (*StepResult, er
 		}
 	}
 
+	runID, runURL := dispatched.RunID, dispatched.URL
 	e.watcher.Watch(runID, step.Workflow)
 
-	//nolint:errcheck // best-effort: run URL is optional display info, nil run is handled below
-	run, _ := e.client.GetWorkflowRun(runID)
-	runURL := ""
-
-	if run != nil {
-		runURL = run.HTMLURL
+	if runURL == "" {
+		//nolint:errcheck // best-effort: run URL is optional display info
+		if run, _ := e.client.GetWorkflowRun(runID); run != nil {
+			runURL = run.HTMLURL
+		}
 	}
 
+	// The run is known from here on, so updates while waiting carry its URL.
 	e.mu.Lock()
 	e.state.StepStatuses[idx] = StepWaiting
+	e.state.StepResults[idx] = &StepResult{
+		Workflow: step.Workflow,
+		Inputs:   inputs,
+		RunID:    runID,
+		RunURL:   runURL,
+		Status:   StepWaiting,
+	}
 	e.mu.Unlock()
 	e.sendUpdate()
 
@@ -378,6 +388,8 @@ func (e *ChainExecutor) waitForRun(runID int64, _ config.WaitCondition) (string,
 	}
 }
 
+// handleStepError records a step that could not be dispatched or waited for,
+// failing the chain when the step aborts on failure.
 func (e *ChainExecutor) handleStepError(idx int, step config.ChainStep, err error) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -392,6 +404,13 @@ func (e *ChainExecutor) handleStepError(idx int, step config.ChainStep, err erro
 	case config.FailureContinue:
 		e.state.StepStatuses[idx] = StepFailed
 	}
+
+	// A run that was dispatched but could not be waited for keeps its URL.
+	if result, ok := e.state.StepResults[idx]; ok {
+		stopped := *result
+		stopped.Status = e.state.StepStatuses[idx]
+		e.state.StepResults[idx] = &stopped
+	}
 }
 
 func (e *ChainExecutor) handleStepFailure(_ int, step config.ChainStep) bool {
@@ -409,6 +428,8 @@ func (e *ChainExecutor) handleStepFailure(_ int, step config.ChainStep) bool {
 	return false
 }
 
+// sendUpdate sends the current state: after each step status change, and
+// once more when the chain completes or fails.
 func (e *ChainExecutor) sendUpdate() {
 	e.mu.RLock()
 	state := *e.state
@@ -6,8 +6,6 @@ import (
 
 	"github.com/kyleking/gh-lazydispatch/internal/chain"
 	"github.com/kyleking/gh-lazydispatch/internal/config"
-	"github.com/kyleking/gh-lazydispatch/internal/exec"
-	"github.com/kyleking/gh-lazydispatch/internal/runner"
 	"github.com/kyleking/gh-lazydispatch/internal/testutil"
 )
 
@@ -45,18 +43,11 @@ func TestNewExecutor(t *testing.T) {
 	}
 }
 
-// TestChainExecutor_Stop mutates the package-level runner.SetExecutor mock,
-// so it cannot run concurrently with other tests that do the same.
-//
-//nolint:paralleltest // mutates the package-level runner.SetExecutor mock, see comment above
-func TestChainExecutor_Stop(_ *testing.T) {
-	mockExec := exec.NewMockExecutor()
-	runner.SetExecutor(mockExec)
-
-	defer runner.SetExecutor(nil)
+func TestChainExecutor_Stop(t *testing.T) {
+	t.Parallel()
 
 	client := testutil.NewMockGitHubClient()
-	client.LatestID = 123
+	client.DispatchedID = 123
 	w := testutil.NewMockRunWatcher()
 	chainDef := &config.Chain{
 		Steps: []config.ChainStep{
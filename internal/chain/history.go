@@ -0,0 +1,35 @@
+package chain
+
+import "github.com/kyleking/gh-lazydispatch/internal/frecency"
+
+// HistoryResults converts step results to the form recorded in history,
+// indexed by step.
+func HistoryResults(stepResults map[int]*StepResult) []frecency.ChainStepResult {
+	if len(stepResults) == 0 {
+		return nil
+	}
+
+	// Find the max index to create properly sized slice
+	maxIdx := -1
+	for idx := range stepResults {
+		if idx > maxIdx {
+			maxIdx = idx
+		}
+	}
+
+	results := make([]frecency.ChainStepResult, maxIdx+1)
+
+	for idx, result := range stepResults {
+		if result != nil {
+			status := string(result.Status)
+			results[idx] = frecency.ChainStepResult{
+				Workflow:   result.Workflow,
+				RunID:      result.RunID,
+				Status:     status,
+				Conclusion: result.Conclusion,
+			}
+		}
+	}
+
+	return results
+}
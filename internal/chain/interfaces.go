@@ -9,7 +9,8 @@ import (
 type GitHubClient interface {
 	GetWorkflowRun(runID int64) (*github.WorkflowRun, error)
 	GetWorkflowRunJobs(runID int64) ([]github.Job, error)
-	GetLatestRun(workflowName string) (*github.WorkflowRun, error)
+	DispatchWorkflow(workflow string, payload github.DispatchPayload) (*github.DispatchResponse, error)
+	FindDispatchedRun(workflow string, dispatched *github.DispatchResponse) (*github.WorkflowRun, error)
 	Owner() string
 	Repo() string
 }
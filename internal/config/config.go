@@ -6,9 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
 )
 
 // ConfigFilename is the default name for the lazydispatch configuration file.
@@ -16,8 +20,53 @@ const ConfigFilename = ".github/lazydispatch.yml"
 
 // WfdConfig represents the lazydispatch configuration file.
 type WfdConfig struct {
-	Chains  map[string]Chain `yaml:"chains"`
-	Version int              `yaml:"version"`
+	Chains map[string]Chain `yaml:"chains"`
+	// Workflows customizes workflow inputs, keyed by workflow filename.
+	Workflows map[string]WorkflowOverride `yaml:"workflows"`
+	Options   OptionsSettings             `yaml:"options"`
+	Version   int                         `yaml:"version"`
+}
+
+// OptionsSettings controls how "# lazydispatch:options:" providers run.
+type OptionsSettings struct {
+	// Commands lets cmd providers run their command from the repository
+	// root. Off by default, since the command comes from a workflow file.
+	Commands bool `yaml:"commands"`
+}
+
+// CommandsAllowed reports whether cmd options providers may run. A nil
+// config allows none.
+func (c *WfdConfig) CommandsAllowed() bool {
+	return c != nil && c.Options.Commands
+}
+
+// WorkflowOverride customizes how one workflow's inputs are presented and
+// validated, and holds its shared presets.
+type WorkflowOverride struct {
+	Inputs  map[string]InputOverride `yaml:"inputs"`
+	Presets map[string]Preset        `yaml:"presets"`
+}
+
+// Preset is a named set of input values for a workflow, committed to the
+// repository so a team can share them.
+type Preset struct {
+	Inputs      map[string]string `yaml:"inputs"`
+	Description string            `yaml:"description,omitempty"`
+	// Branch is the branch to dispatch on, if the preset pins one.
+	Branch string `yaml:"branch,omitempty"`
+}
+
+// InputOverride customizes one workflow input without editing the workflow file.
+type InputOverride struct {
+	// Default replaces the workflow's default when set.
+	Default *string `yaml:"default"`
+	Label   string  `yaml:"label"`
+	// Validate holds extra rules written as in validation comments, such as "regex:^v\d+".
+	Validate []string              `yaml:"validate"`
+	Rules    []rule.ValidationRule `yaml:"-"`
+	Order    int                   `yaml:"order"`
+	Hidden   bool                  `yaml:"hidden"`
+	Locked   bool                  `yaml:"locked"`
 }
 
 // ChainVariable represents a variable that can be set when running a chain.
@@ -73,10 +122,31 @@ var ErrConfigNotFound = errors.New("config file not found")
 // ErrUnsupportedConfigVersion indicates the configuration file declares an unsupported version.
 var ErrUnsupportedConfigVersion = errors.New("unsupported config version (expected 1 or 2)")
 
-// Load loads the configuration from the default location.
+// Load loads the configuration from the default location, reading the values
+// files of oneof-file rules relative to repoRoot. Values files cannot be read
+// from outside repoRoot, even through a symlink. One that cannot be read is
+// recorded on its rule, failing every value checked against it, rather than
+// failing the load.
 func Load(repoRoot string) (*WfdConfig, error) {
 	configPath := filepath.Join(repoRoot, ConfigFilename)
-	return LoadFrom(configPath)
+
+	config, err := LoadFrom(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck,gosec // unreadable values files are recorded on their rules
+	config.LoadAllowedValues(func(path string) ([]byte, error) {
+		root, err := os.OpenRoot(repoRoot)
+		if err != nil {
+			return nil, fmt.Errorf("opening repository root: %w", err)
+		}
+		defer root.Close() //nolint:errcheck // nothing was written through the read-only root
+
+		return root.ReadFile(filepath.FromSlash(path)) //nolint:wrapcheck // LoadAllowedValues names the path
+	})
+
+	return config, nil
 }
 
 // LoadFrom loads the configuration from a specific path.
@@ -91,6 +161,12 @@ func LoadFrom(path string) (*WfdConfig, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	return Parse(data)
+}
+
+// Parse parses configuration file contents, such as a file fetched from a
+// remote repository, applying the same defaults as LoadFrom.
+func Parse(data []byte) (*WfdConfig, error) {
 	var config WfdConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
@@ -120,9 +196,106 @@ func LoadFrom(path string) (*WfdConfig, error) {
 		config.Chains[name] = chain
 	}
 
+	for filename, override := range config.Workflows {
+		for name, input := range override.Inputs {
+			for _, spec := range input.Validate {
+				r, err := rule.ParseRuleSpec(spec)
+				if err != nil {
+					return nil, fmt.Errorf("workflows.%s.inputs.%s: %w", filename, name, err)
+				}
+
+				input.Rules = append(input.Rules, *r)
+			}
+
+			override.Inputs[name] = input
+		}
+	}
+
 	return &config, nil
 }
 
+// LoadAllowedValues reads the values files of oneof-file rules in the input
+// overrides through read, which resolves repository-relative paths. Every
+// rule is loaded even when some files cannot be read; those are recorded on
+// their rules and reported together in the returned error.
+func (c *WfdConfig) LoadAllowedValues(read func(path string) ([]byte, error)) error {
+	var errs []error
+
+	for filename, override := range c.Workflows {
+		for name, input := range override.Inputs {
+			rules, err := rule.LoadAllowedValues(input.Rules, read)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("workflows.%s.inputs.%s: %w", filename, name, err))
+			}
+
+			input.Rules = rules
+			override.Inputs[name] = input
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// OverrideWorkflow returns wf with the configured input overrides applied.
+// The workflow's input map is copied, so wf itself is left unchanged.
+func (c *WfdConfig) OverrideWorkflow(wf workflow.File) workflow.File {
+	if c == nil || wf.On.Dispatch == nil {
+		return wf
+	}
+
+	override, ok := c.Workflows[wf.Filename]
+	if !ok || len(override.Inputs) == 0 {
+		return wf
+	}
+
+	inputs := make(map[string]workflow.Input, len(wf.On.Dispatch.Inputs))
+
+	for name, input := range wf.On.Dispatch.Inputs {
+		if o, ok := override.Inputs[name]; ok {
+			if o.Default != nil {
+				input.Default = *o.Default
+			}
+
+			input.Label = o.Label
+			input.Order = o.Order
+			input.Hidden = o.Hidden
+			input.Locked = o.Locked
+			input.ValidationRules = append(slices.Clip(input.ValidationRules), o.Rules...)
+		}
+
+		inputs[name] = input
+	}
+
+	dispatch := *wf.On.Dispatch
+	dispatch.Inputs = inputs
+	wf.On.Dispatch = &dispatch
+
+	return wf
+}
+
+// ApplyOverrides returns workflows with the configured input overrides applied.
+func (c *WfdConfig) ApplyOverrides(workflows []workflow.File) []workflow.File {
+	if c == nil || len(c.Workflows) == 0 {
+		return workflows
+	}
+
+	overridden := make([]workflow.File, len(workflows))
+	for i, wf := range workflows {
+		overridden[i] = c.OverrideWorkflow(wf)
+	}
+
+	return overridden
+}
+
+// Presets returns the presets defined for the workflow with the given filename.
+func (c *WfdConfig) Presets(filename string) map[string]Preset {
+	if c == nil {
+		return nil
+	}
+
+	return c.Workflows[filename].Presets
+}
+
 // GetChain returns a chain by name.
 func (c *WfdConfig) GetChain(name string) (*Chain, bool) {
 	if c == nil || c.Chains == nil {
@@ -4,9 +4,13 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
 )
 
 func TestLoad_ValidConfig(t *testing.T) {
@@ -431,3 +435,189 @@ chains:
 		t.Errorf("default type: got %q, want %q", v.Type, "string")
 	}
 }
+
+func TestParse_WorkflowOverrides(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte(`version: 2
+workflows:
+  deploy.yml:
+    inputs:
+      version:
+        label: Release version
+        order: 1
+        validate:
+          - "regex:^v\\d+\\.\\d+\\.\\d+$"
+          - "length:1-20"
+      environment:
+        default: production
+        locked: true
+      debug:
+        hidden: true
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inputs := cfg.Workflows["deploy.yml"].Inputs
+
+	if got := inputs["version"]; got.Label != "Release version" || got.Order != 1 || len(got.Rules) != 2 {
+		t.Errorf("unexpected version override %+v", got)
+	}
+
+	if got := inputs["environment"]; got.Default == nil || *got.Default != "production" || !got.Locked {
+		t.Errorf("unexpected environment override %+v", got)
+	}
+
+	if !inputs["debug"].Hidden {
+		t.Error("expected debug to be hidden")
+	}
+}
+
+func TestParse_WorkflowOverrideInvalidRule(t *testing.T) {
+	t.Parallel()
+
+	_, err := config.Parse([]byte(`version: 2
+workflows:
+  deploy.yml:
+    inputs:
+      version:
+        validate: ["checksum"]
+`))
+	if !errors.Is(err, rule.ErrUnknownRuleType) {
+		t.Fatalf("expected ErrUnknownRuleType, got %v", err)
+	}
+
+	if !strings.Contains(err.Error(), "workflows.deploy.yml.inputs.version") {
+		t.Errorf("expected error to name the input, got %v", err)
+	}
+}
+
+func TestApplyOverrides(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.Parse([]byte(`version: 2
+workflows:
+  deploy.yml:
+    inputs:
+      version:
+        label: Release version
+        validate: ["prefix:v"]
+      environment:
+        default: production
+        hidden: true
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	original := []workflow.File{
+		{
+			Filename: "deploy.yml",
+			On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+				"version": {
+					ValidationRules: []rule.ValidationRule{{Type: rule.RuleRequired}},
+				},
+				"environment": {Default: "staging"},
+				"dry_run":     {Default: "false"},
+			}}},
+		},
+		{Filename: "ci.yml", On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{}}},
+	}
+
+	got := cfg.ApplyOverrides(original)
+
+	inputs := got[0].GetInputs()
+
+	if v := inputs["version"]; v.Label != "Release version" || len(v.ValidationRules) != 2 {
+		t.Errorf("expected label and appended rule, got %+v", v)
+	}
+
+	if env := inputs["environment"]; env.Default != "production" || !env.Hidden {
+		t.Errorf("expected default override and hidden, got %+v", env)
+	}
+
+	if inputs["dry_run"].Default != "false" {
+		t.Error("expected inputs without overrides to be kept")
+	}
+
+	if orig := original[0].GetInputs()["environment"]; orig.Default != "staging" || orig.Hidden {
+		t.Errorf("expected original workflow to be unchanged, got %+v", orig)
+	}
+
+	if len(original[0].GetInputs()["version"].ValidationRules) != 1 {
+		t.Error("expected original validation rules to be unchanged")
+	}
+
+	var none *config.WfdConfig
+	if got := none.ApplyOverrides(original); &got[0] != &original[0] {
+		t.Error("expected a nil config to return the workflows as-is")
+	}
+}
+
+func TestLoad_OneOfFileValues(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	configDir := filepath.Join(dir, ".github")
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		t.Fatalf("failed to create .github dir: %v", err)
+	}
+
+	configContent := `version: 2
+workflows:
+  deploy.yml:
+    inputs:
+      region:
+        validate: ["oneof-file:.github/regions.txt"]
+`
+	if err := os.WriteFile(filepath.Join(configDir, "lazydispatch.yml"), []byte(configContent), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("expected a missing values file not to fail the load, got %v", err)
+	}
+
+	if rules := cfg.Workflows["deploy.yml"].Inputs["region"].Rules; !strings.Contains(rules[0].ValuesErr, "regions.txt") {
+		t.Errorf("expected the missing values file recorded on its rule, got %+v", rules)
+	}
+
+	outside := filepath.Join(t.TempDir(), "regions.txt")
+	if err := os.WriteFile(outside, []byte("us-east-1\n"), 0o600); err != nil {
+		t.Fatalf("failed to write values file: %v", err)
+	}
+
+	if err := os.Symlink(outside, filepath.Join(configDir, "regions.txt")); err != nil {
+		t.Fatalf("failed to link values file: %v", err)
+	}
+
+	cfg, err = config.Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rules := cfg.Workflows["deploy.yml"].Inputs["region"].Rules; rules[0].Values != nil || rules[0].ValuesErr == "" {
+		t.Errorf("expected a values file linked from outside the repository to be refused, got %+v", rules)
+	}
+
+	if err := os.Remove(filepath.Join(configDir, "regions.txt")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(configDir, "regions.txt"), []byte("us-east-1\neu-west-1\n"), 0o600); err != nil {
+		t.Fatalf("failed to write values file: %v", err)
+	}
+
+	cfg, err = config.Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rules := cfg.Workflows["deploy.yml"].Inputs["region"].Rules
+	if len(rules) != 1 || !slices.Equal(rules[0].Values, []string{"us-east-1", "eu-west-1"}) {
+		t.Errorf("expected the allowed values to be loaded, got %+v", rules)
+	}
+}
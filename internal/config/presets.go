@@ -0,0 +1,153 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The config file is committed, so it is written with the usual permissions
+// rather than the private ones used for caches.
+const (
+	configDirPerm  = 0o755
+	configFilePerm = 0o644
+	defaultIndent  = 2
+)
+
+// Errors returned while saving a preset.
+var (
+	ErrPresetNameEmpty  = errors.New("preset name is empty")
+	ErrConfigNotMapping = errors.New("not a YAML mapping")
+)
+
+// SavePreset writes preset under workflows.<filename>.presets.<name> in the
+// config file at path, replacing a preset of the same name. A missing file is
+// created with version 1.
+//
+// The file is decoded and encoded again, so its content, comments and
+// indentation width are kept but the layout is normalized: quoting, flow
+// style and blank lines follow the encoder's defaults.
+func SavePreset(path, filename, name string, preset Preset) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrPresetNameEmpty
+	}
+
+	var doc yaml.Node
+
+	data, err := os.ReadFile(path) //nolint:gosec // path built from repo root + fixed config filename
+	switch {
+	case os.IsNotExist(err):
+		data = []byte("version: 1\n")
+	case err != nil:
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	presets := doc.Content[0]
+
+	for _, key := range []string{"workflows", filename, "presets"} {
+		if presets, err = mappingValue(presets, key); err != nil {
+			return err
+		}
+	}
+
+	var value yaml.Node
+	if err := value.Encode(preset); err != nil {
+		return fmt.Errorf("failed to encode preset: %w", err)
+	}
+
+	setMappingValue(presets, name, &value)
+
+	var buf bytes.Buffer
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indentWidth(data))
+
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), configDirPerm); err != nil { //nolint:gosec // committed directory
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), configFilePerm); err != nil { //nolint:gosec // committed file
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// mappingValue returns the mapping stored under key in node, creating it if
+// the key is missing or empty. An empty flow mapping becomes a block mapping.
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: %w", key, ErrConfigNotMapping)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+
+		value := node.Content[i+1]
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			*value = yaml.Node{Kind: yaml.MappingNode}
+		}
+
+		if value.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("%s: %w", key, ErrConfigNotMapping)
+		}
+
+		// An empty "{}" would otherwise print everything added to it inline.
+		if len(value.Content) == 0 {
+			value.Style = 0
+		}
+
+		return value, nil
+	}
+
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	setMappingValue(node, key, value)
+
+	return value, nil
+}
+
+// setMappingValue sets key to value in the mapping node, appending the key if
+// it is not already present.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+// indentWidth returns the indentation of the first indented line in data, so
+// a rewritten file keeps its indentation, or defaultIndent if none is.
+func indentWidth(data []byte) int {
+	for line := range strings.Lines(string(data)) {
+		trimmed := strings.TrimLeft(line, " ")
+		if width := len(line) - len(trimmed); width > 0 && strings.TrimSpace(trimmed) != "" &&
+			!strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, "- ") {
+			return width
+		}
+	}
+
+	return defaultIndent
+}
@@ -0,0 +1,133 @@
+package config_test
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+)
+
+func TestSavePreset(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lazydispatch.yml")
+	original := `version: 1
+# Shared presets for the deploy workflow.
+workflows:
+  deploy.yml:
+    inputs:
+      version:
+        label: Release version
+    presets:
+      staging-canary:
+        inputs:
+          environment: staging
+`
+
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	preset := config.Preset{
+		Description: "Everything, everywhere",
+		Inputs:      map[string]string{"environment": "production", "dry_run": "false"},
+	}
+	if err := config.SavePreset(path, "deploy.yml", "prod-full", preset); err != nil {
+		t.Fatalf("SavePreset failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+
+	if !strings.Contains(string(data), "# Shared presets for the deploy workflow.") {
+		t.Errorf("expected comments to be kept:\n%s", data)
+	}
+
+	cfg, err := config.Parse(data)
+	if err != nil {
+		t.Fatalf("saved config does not parse: %v\n%s", err, data)
+	}
+
+	presets := cfg.Presets("deploy.yml")
+	if len(presets) != 2 {
+		t.Fatalf("expected both presets, got %v", presets)
+	}
+
+	if got := presets["prod-full"]; !maps.Equal(got.Inputs, preset.Inputs) || got.Description != preset.Description {
+		t.Errorf("prod-full = %+v, want %+v", got, preset)
+	}
+
+	if label := cfg.Workflows["deploy.yml"].Inputs["version"].Label; label != "Release version" {
+		t.Errorf("expected the input overrides to be kept, got label %q", label)
+	}
+}
+
+func TestSavePreset_KeepsIndentation(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lazydispatch.yml")
+	original := "version: 1\nworkflows:\n    deploy.yml:\n        presets: {}\n"
+
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	preset := config.Preset{Inputs: map[string]string{"env": "staging"}}
+	if err := config.SavePreset(path, "deploy.yml", "staging", preset); err != nil {
+		t.Fatalf("SavePreset failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+
+	if !strings.Contains(string(data), "\n    deploy.yml:\n        presets:\n            staging:\n") {
+		t.Errorf("expected four-space indentation to be kept:\n%s", data)
+	}
+}
+
+func TestSavePreset_CreatesConfig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".github", "lazydispatch.yml")
+
+	preset := config.Preset{Inputs: map[string]string{"replicas": "3"}, Branch: "main"}
+	if err := config.SavePreset(path, "scale.yml", "three", preset); err != nil {
+		t.Fatalf("SavePreset failed: %v", err)
+	}
+
+	cfg, err := config.LoadFrom(path)
+	if err != nil {
+		t.Fatalf("created config does not load: %v", err)
+	}
+
+	if got := cfg.Presets("scale.yml")["three"]; got.Branch != "main" || got.Inputs["replicas"] != "3" {
+		t.Errorf("unexpected preset %+v", got)
+	}
+}
+
+func TestSavePreset_Errors(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lazydispatch.yml")
+	if err := os.WriteFile(path, []byte("version: 1\nworkflows: [deploy.yml]\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	preset := config.Preset{Inputs: map[string]string{}}
+
+	if err := config.SavePreset(path, "deploy.yml", " ", preset); !errors.Is(err, config.ErrPresetNameEmpty) {
+		t.Errorf("expected ErrPresetNameEmpty, got %v", err)
+	}
+
+	if err := config.SavePreset(path, "deploy.yml", "p", preset); !errors.Is(err, config.ErrConfigNotMapping) {
+		t.Errorf("expected ErrConfigNotMapping, got %v", err)
+	}
+}
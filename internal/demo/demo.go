@@ -26,9 +26,9 @@ const (
 	demoJobIDDeployStaging    = 2003
 	demoJobIDDeployProduction = 2004
 
-	demoLatestRunIDCI      = 1004
-	demoLatestRunIDDeploy  = 1005
-	demoLatestRunIDRelease = 1006
+	demoDispatchedRunIDCI      = 1004
+	demoDispatchedRunIDDeploy  = 1005
+	demoDispatchedRunIDRelease = 1006
 )
 
 // MockConfig holds configuration for demo mode.
@@ -104,10 +104,10 @@ func (c *MockConfig) setupWorkflowDispatch() {
 	c.Executor.AddGHWorkflowRun("deploy.yml", "main", map[string]string{environmentInput: "production"})
 	c.Executor.AddGHWorkflowRun("release.yml", "main", map[string]string{"version": "1.0.0"})
 
-	// Mock latest run lookup after dispatch
-	c.Executor.AddGHAPILatestRun(c.Owner, c.Repo, "ci.yml", demoLatestRunIDCI, github.StatusQueued)
-	c.Executor.AddGHAPILatestRun(c.Owner, c.Repo, "deploy.yml", demoLatestRunIDDeploy, github.StatusQueued)
-	c.Executor.AddGHAPILatestRun(c.Owner, c.Repo, "release.yml", demoLatestRunIDRelease, github.StatusQueued)
+	// Mock API dispatches, which return the started run
+	c.Executor.AddGHWorkflowDispatch(c.Owner, c.Repo, "ci.yml", demoDispatchedRunIDCI)
+	c.Executor.AddGHWorkflowDispatch(c.Owner, c.Repo, "deploy.yml", demoDispatchedRunIDDeploy)
+	c.Executor.AddGHWorkflowDispatch(c.Owner, c.Repo, "release.yml", demoDispatchedRunIDRelease)
 }
 
 func (c *MockConfig) addWorkflowRun(runID int64, _, status, conclusion string) {
@@ -126,7 +126,7 @@ func (c *MockConfig) addRunJobs(runID int64, jobs []github.Job) {
 }
 
 func (c *MockConfig) addRunLogs(runID, jobID int64, logs string) {
-	c.Executor.AddGHRunView(runID, jobID, logs)
+	c.Executor.AddGHRunView(c.Owner, c.Repo, runID, jobID, logs)
 }
 
 // Workflows returns a set of demo workflows for testing the UI.
@@ -143,3 +143,27 @@ func TestInstallUninstall(t *testing.T) {
 	cfg.Install()
 	cfg.Uninstall()
 }
+
+func TestMockExecutor_Dispatch(t *testing.T) {
+	t.Parallel()
+
+	cfg := demo.NewMockConfig()
+	cfg.SetupMockExecutor()
+
+	client, err := github.NewClientWithExecutor("demo-org/demo-repo", cfg.Executor)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	resp, err := client.DispatchWorkflow("deploy.yml", github.DispatchPayload{
+		Ref:    "main",
+		Inputs: map[string]any{"environment": "staging"},
+	})
+	if err != nil {
+		t.Fatalf("DispatchWorkflow failed: %v", err)
+	}
+
+	if resp.RunID != 1005 {
+		t.Errorf("resp.RunID = %d, want 1005", resp.RunID)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 	"testing"
@@ -24,6 +25,8 @@ type CommandExecutor interface {
 	// Execute runs a command with the given name and arguments.
 	// Returns stdout, stderr, and any error.
 	Execute(name string, args ...string) (stdout, stderr string, err error)
+	// ExecuteWithStdin is like Execute but writes stdin to the command's standard input.
+	ExecuteWithStdin(stdin, name string, args ...string) (stdout, stderr string, err error)
 }
 
 // RealExecutor executes actual system commands.
@@ -38,7 +41,32 @@ func NewRealExecutor() *RealExecutor {
 // It includes a safety check to prevent accidental mutation of GitHub resources during tests.
 //
 //nolint:nonamedreturns // gocritic wants named returns matching the CommandExecutor interface
-func (*RealExecutor) Execute(name string, args ...string) (stdout, stderr string, err error) {
+func (e *RealExecutor) Execute(name string, args ...string) (stdout, stderr string, err error) {
+	return e.run(context.Background(), "", nil, name, args)
+}
+
+// ExecuteWithStdin runs the actual command with stdin as its standard input,
+// under the same safety check as Execute.
+//
+//nolint:nonamedreturns // gocritic wants named returns matching the CommandExecutor interface
+func (e *RealExecutor) ExecuteWithStdin(stdin, name string, args ...string) (stdout, stderr string, err error) {
+	return e.run(context.Background(), "", strings.NewReader(stdin), name, args)
+}
+
+// ExecuteIn runs the actual command in dir, killing it when ctx is done,
+// under the same safety check as Execute.
+//
+//nolint:nonamedreturns // gocritic wants named returns matching the CommandExecutor interface
+func (e *RealExecutor) ExecuteIn(
+	ctx context.Context, dir, name string, args ...string,
+) (stdout, stderr string, err error) {
+	return e.run(ctx, dir, nil, name, args)
+}
+
+//nolint:nonamedreturns // gocritic wants named returns matching the CommandExecutor interface
+func (*RealExecutor) run(
+	ctx context.Context, dir string, stdin io.Reader, name string, args []string,
+) (stdout, stderr string, err error) {
 	// Safety check: Prevent mutation commands during tests
 	if testing.Testing() && isMutationCommand(name, args) {
 		panic(fmt.Sprintf(
@@ -50,7 +78,9 @@ func (*RealExecutor) Execute(name string, args ...string) (stdout, stderr string
 	}
 
 	// #nosec G204 -- deliberate exec wrapper; callers pass fixed binaries with internal args
-	cmd := exec.CommandContext(context.Background(), name, args...)
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Stdin = stdin
 
 	var stdoutBuf, stderrBuf bytes.Buffer
 
@@ -94,6 +124,10 @@ func isMutationCommand(name string, args []string) bool {
 
 	subcommand := args[0]
 
+	if subcommand == ghAPISubcommand {
+		return isAPIMutation(args[1:])
+	}
+
 	// Special case: "gh run view" is read-only, but "gh run cancel/rerun" are mutations
 	if subcommand == ghRunSubcommand && len(args) > 1 {
 		operation := args[1]
@@ -107,3 +141,24 @@ func isMutationCommand(name string, args []string) bool {
 
 	return mutationCommands[subcommand]
 }
+
+// isAPIMutation checks if gh api arguments send anything other than a GET:
+// an explicit non-GET method, or a request body, which makes gh default to POST.
+func isAPIMutation(args []string) bool {
+	hasBody := false
+
+	for i, arg := range args {
+		switch {
+		case arg == "-X" || arg == "--method":
+			if i+1 < len(args) {
+				return !strings.EqualFold(args[i+1], "GET")
+			}
+		case strings.HasPrefix(arg, "--method="):
+			return !strings.EqualFold(strings.TrimPrefix(arg, "--method="), "GET")
+		case arg == "-f" || arg == "-F" || arg == "--field" || arg == "--raw-field" || arg == "--input":
+			hasBody = true
+		}
+	}
+
+	return hasBody
+}
@@ -1,13 +1,16 @@
 package exec
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 )
 
-// MockExecutor simulates command execution for testing.
+// MockExecutor simulates command execution for testing. Execute is safe for
+// concurrent use; configure commands before executing any.
 type MockExecutor struct {
 	// Commands maps command patterns to responses.
 	// Key format: "command arg1 arg2"
@@ -18,6 +21,8 @@ type MockExecutor struct {
 
 	// ExecutedCommands tracks all commands that were executed.
 	ExecutedCommands []ExecutedCommand
+
+	mu sync.Mutex
 }
 
 // ErrMockCommandNotConfigured indicates the MockExecutor has no result configured for a command.
@@ -39,6 +44,10 @@ type CommandResult struct {
 // ExecutedCommand tracks a command that was executed.
 type ExecutedCommand struct {
 	Name string
+	// Stdin is what ExecuteWithStdin wrote to the command's standard input.
+	Stdin string
+	// Dir is the directory ExecuteIn ran the command in.
+	Dir  string
 	Args []string
 }
 
@@ -54,11 +63,38 @@ func NewMockExecutor() *MockExecutor {
 //
 //nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
 func (m *MockExecutor) Execute(name string, args ...string) (string, string, error) {
+	return m.ExecuteWithStdin("", name, args...)
+}
+
+// ExecuteWithStdin is like Execute, recording stdin with the executed command.
+// Commands are matched on their name and arguments only.
+//
+//nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
+func (m *MockExecutor) ExecuteWithStdin(stdin, name string, args ...string) (string, string, error) {
+	return m.execute(ExecutedCommand{Name: name, Args: args, Stdin: stdin})
+}
+
+// ExecuteIn is like Execute, recording dir with the executed command. It
+// fails with ctx's error if ctx is already done.
+//
+//nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
+func (m *MockExecutor) ExecuteIn(ctx context.Context, dir, name string, args ...string) (string, string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", "", fmt.Errorf("mock executor: %w", err)
+	}
+
+	return m.execute(ExecutedCommand{Name: name, Args: args, Dir: dir})
+}
+
+//nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
+func (m *MockExecutor) execute(cmd ExecutedCommand) (string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name, args := cmd.Name, cmd.Args
+
 	// Track the executed command
-	m.ExecutedCommands = append(m.ExecutedCommands, ExecutedCommand{
-		Name: name,
-		Args: args,
-	})
+	m.ExecutedCommands = append(m.ExecutedCommands, cmd)
 
 	// Build command key
 	cmdKey := m.buildCommandKey(name, args)
@@ -95,22 +131,26 @@ func (m *MockExecutor) AddCommand(name string, args []string, stdout, stderr str
 }
 
 // AddGHRunView is a convenience method for adding gh run view commands.
-func (m *MockExecutor) AddGHRunView(runID, jobID int64, logOutput string) {
+func (m *MockExecutor) AddGHRunView(owner, repo string, runID, jobID int64, logOutput string) {
 	args := []string{ghRunSubcommand, ghViewOperation, strconv.FormatInt(runID, 10), ghLogFlag}
 	if jobID > 0 {
 		args = append(args, "--job", strconv.FormatInt(jobID, 10))
 	}
 
+	args = append(args, "--repo", owner+"/"+repo)
+
 	m.AddCommand("gh", args, logOutput, "", nil)
 }
 
 // AddGHRunViewError is a convenience method for adding failing gh run view commands.
-func (m *MockExecutor) AddGHRunViewError(runID, jobID int64, stderr string, err error) {
+func (m *MockExecutor) AddGHRunViewError(owner, repo string, runID, jobID int64, stderr string, err error) {
 	args := []string{ghRunSubcommand, ghViewOperation, strconv.FormatInt(runID, 10), ghLogFlag}
 	if jobID > 0 {
 		args = append(args, "--job", strconv.FormatInt(jobID, 10))
 	}
 
+	args = append(args, "--repo", owner+"/"+repo)
+
 	m.AddCommand("gh", args, "", stderr, err)
 }
 
@@ -174,6 +214,18 @@ func (m *MockExecutor) AddGHAPILatestRun(owner, repo, workflow string, runID int
 	m.AddCommand("gh", []string{ghAPISubcommand, path}, runsJSON, "", nil)
 }
 
+// AddGHWorkflowDispatch mocks a gh api call dispatching workflow, answered
+// with the details of run runID. The request body is not matched.
+func (m *MockExecutor) AddGHWorkflowDispatch(owner, repo, workflow string, runID int64) {
+	path := fmt.Sprintf("repos/%s/%s/actions/workflows/%s/dispatches", owner, repo, workflow)
+	runJSON := fmt.Sprintf(
+		`{"workflow_run_id":%d,"run_url":"https://api.github.com/repos/%s/%s/actions/runs/%d",`+
+			`"html_url":"https://github.com/%s/%s/actions/runs/%d"}`,
+		runID, owner, repo, runID, owner, repo, runID,
+	)
+	m.AddCommand("gh", []string{ghAPISubcommand, "-X", "POST", path, "--input", "-"}, runJSON, "", nil)
+}
+
 // AddGHVersion mocks the gh --version command.
 func (m *MockExecutor) AddGHVersion(version string) {
 	m.AddCommand("gh", []string{"--version"}, fmt.Sprintf("gh version %s (2024-01-01)", version), "", nil)
@@ -126,6 +126,24 @@ func TestIsMutationCommand(t *testing.T) {
 			args:       []string{"api", "repos/owner/repo/actions/runs"},
 			isMutation: false,
 		},
+		{
+			name:       "gh api GET with query fields is read-only",
+			command:    "gh",
+			args:       []string{"api", "-X", "GET", "search/issues", "-f", "q=repo:owner/repo"},
+			isMutation: false,
+		},
+		{
+			name:       "gh api POST is mutation",
+			command:    "gh",
+			args:       []string{"api", "-X", "POST", "repos/owner/repo/actions/workflows/ci.yml/dispatches"},
+			isMutation: true,
+		},
+		{
+			name:       "gh api with a body defaults to POST",
+			command:    "gh",
+			args:       []string{"api", "repos/owner/repo/issues", "--input", "-"},
+			isMutation: true,
+		},
 		{
 			name:       "non-gh command is safe",
 			command:    "echo",
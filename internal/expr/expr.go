@@ -0,0 +1,509 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Errors returned while parsing or evaluating expressions.
+var (
+	// ErrSyntax indicates the expression is malformed.
+	ErrSyntax = errors.New("syntax error")
+	// ErrUnknown indicates the expression depends on something that cannot be
+	// known before the run, such as secrets, vars or step outputs.
+	ErrUnknown = errors.New("cannot be evaluated before the run")
+)
+
+// statusFunctions are the job status checks, with the result they have when
+// every needed job succeeds, which is what a preview assumes.
+var statusFunctions = map[string]bool{
+	"success":   true,
+	"always":    true,
+	"failure":   false,
+	"cancelled": false,
+}
+
+// Context holds the values that expressions can read.
+type Context struct {
+	// Inputs holds the workflow_dispatch inputs, keyed by name.
+	Inputs map[string]Value
+	// Github holds properties of the github context, keyed by their dotted
+	// path below it, such as "ref_name" or "event.inputs.deploy".
+	Github map[string]Value
+}
+
+// Expression is a parsed expression, ready to evaluate against a Context.
+type Expression struct {
+	root        node
+	checkStatus bool
+}
+
+// Parse parses an expression, with or without its ${{ }} delimiters.
+func Parse(src string) (*Expression, error) {
+	src = strings.TrimSpace(src)
+	if inner, ok := strings.CutPrefix(src, "${{"); ok {
+		if inner, ok = strings.CutSuffix(inner, "}}"); ok && !strings.Contains(inner, "${{") {
+			src = inner
+		}
+	}
+
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, tok.value, tok.pos)
+	}
+
+	return &Expression{root: root, checkStatus: p.checkStatus}, nil
+}
+
+// Evaluate evaluates the expression. It returns an error wrapping ErrUnknown
+// when the result depends on values the context does not hold.
+func (e *Expression) Evaluate(ctx Context) (Value, error) {
+	return e.root.eval(ctx)
+}
+
+// ChecksStatus reports whether the expression calls a status check function
+// such as always() or failure(). Conditions without one are implicitly
+// combined with success(), so they are skipped when a needed job is.
+func (e *Expression) ChecksStatus() bool {
+	return e.checkStatus
+}
+
+// node is an element of a parsed expression.
+type node interface {
+	eval(ctx Context) (Value, error)
+}
+
+type literal struct {
+	value Value
+}
+
+func (n literal) eval(Context) (Value, error) {
+	return n.value, nil
+}
+
+// property is a context access such as inputs.deploy or github['ref_name'].
+type property struct {
+	root string
+	keys []node
+}
+
+func (n property) eval(ctx Context) (Value, error) {
+	keys := make([]string, len(n.keys))
+
+	for i, key := range n.keys {
+		v, err := key.eval(ctx)
+		if err != nil {
+			return Value{}, err
+		}
+
+		keys[i] = strings.ToLower(v.String())
+	}
+
+	switch strings.ToLower(n.root) {
+	case "inputs":
+		// Inputs the workflow does not declare read as null, as on GitHub.
+		if len(keys) != 1 {
+			return Value{}, fmt.Errorf("%w: inputs.%s", ErrUnknown, strings.Join(keys, "."))
+		}
+
+		return lookup(ctx.Inputs, keys[0], Null()), nil
+	case "github":
+		path := strings.Join(keys, ".")
+		if v, ok := lookupOK(ctx.Github, path); ok {
+			return v, nil
+		}
+
+		return Value{}, fmt.Errorf("%w: github.%s", ErrUnknown, path)
+	}
+
+	return Value{}, fmt.Errorf("%w: %s", ErrUnknown, n.root)
+}
+
+// lookup returns the value for key, ignoring case as GitHub does, or fallback.
+func lookup(values map[string]Value, key string, fallback Value) Value {
+	if v, ok := lookupOK(values, key); ok {
+		return v
+	}
+
+	return fallback
+}
+
+func lookupOK(values map[string]Value, key string) (Value, bool) {
+	if v, ok := values[key]; ok {
+		return v, true
+	}
+
+	for k, v := range values {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+
+	return Value{}, false
+}
+
+type not struct {
+	operand node
+}
+
+func (n not) eval(ctx Context) (Value, error) {
+	v, err := n.operand.eval(ctx)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return Bool(!v.Truthy()), nil
+}
+
+type binary struct {
+	left, right node
+	op          string
+}
+
+func (n binary) eval(ctx Context) (Value, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return Value{}, err
+	}
+
+	// && and || short-circuit and yield one of their operands.
+	switch {
+	case n.op == "&&" && !left.Truthy(), n.op == "||" && left.Truthy():
+		return left, nil
+	case n.op == "&&" || n.op == "||":
+		return n.right.eval(ctx)
+	}
+
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return Value{}, err
+	}
+
+	switch n.op {
+	case "==":
+		return Bool(equal(left, right)), nil
+	case "!=":
+		return Bool(!equal(left, right)), nil
+	}
+
+	order, ok := compare(left, right)
+
+	switch n.op {
+	case "<":
+		return Bool(ok && order < 0), nil
+	case "<=":
+		return Bool(ok && order <= 0), nil
+	case ">":
+		return Bool(ok && order > 0), nil
+	default:
+		return Bool(ok && order >= 0), nil
+	}
+}
+
+type call struct {
+	name string
+	args []node
+}
+
+func (n call) eval(ctx Context) (Value, error) {
+	if result, ok := statusFunctions[n.name]; ok {
+		return Bool(result), nil
+	}
+
+	args := make([]Value, len(n.args))
+
+	for i, arg := range n.args {
+		v, err := arg.eval(ctx)
+		if err != nil {
+			return Value{}, err
+		}
+
+		args[i] = v
+	}
+
+	switch n.name {
+	case "contains":
+		return Bool(strings.Contains(strings.ToLower(args[0].String()), strings.ToLower(args[1].String()))), nil
+	case "startswith":
+		return Bool(strings.HasPrefix(strings.ToLower(args[0].String()), strings.ToLower(args[1].String()))), nil
+	case "endswith":
+		return Bool(strings.HasSuffix(strings.ToLower(args[0].String()), strings.ToLower(args[1].String()))), nil
+	default:
+		return format(args[0].String(), args[1:])
+	}
+}
+
+// format replaces {N} with the Nth argument; {{ and }} escape braces.
+func format(pattern string, args []Value) (Value, error) {
+	var sb strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(pattern) && pattern[i+1] == c:
+			sb.WriteByte(c)
+			i++
+		case c == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return Value{}, fmt.Errorf("%w: unclosed { in format string", ErrSyntax)
+			}
+
+			index, err := strconv.Atoi(pattern[i+1 : i+end])
+			if err != nil || index < 0 || index >= len(args) {
+				return Value{}, fmt.Errorf("%w: invalid format placeholder %s", ErrSyntax, pattern[i:i+end+1])
+			}
+
+			sb.WriteString(args[index].String())
+			i += end
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return String(sb.String()), nil
+}
+
+// functionArity lists the supported functions with their minimum and maximum
+// argument counts; -1 means any number.
+var functionArity = map[string][2]int{
+	"contains":   {2, 2},
+	"startswith": {2, 2},
+	"endswith":   {2, 2},
+	"format":     {1, -1},
+	"success":    {0, 0},
+	"always":     {0, 0},
+	"failure":    {0, 0},
+	"cancelled":  {0, 0},
+}
+
+// parser is a recursive descent parser. Precedence from lowest to highest:
+// ||, &&, == and !=, comparisons, !, then literals, properties and calls.
+type parser struct {
+	tokens      []token
+	pos         int
+	checkStatus bool
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+// accept consumes the next token if it is the operator op.
+func (p *parser) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokenOperator && tok.value == op {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if p.accept(op) {
+		return nil
+	}
+
+	tok := p.peek()
+
+	return fmt.Errorf("%w: expected %q at position %d", ErrSyntax, op, tok.pos)
+}
+
+func (p *parser) parseOr() (node, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *parser) parseAnd() (node, error) {
+	return p.parseBinary(p.parseEquality, "&&")
+}
+
+func (p *parser) parseEquality() (node, error) {
+	return p.parseBinary(p.parseComparison, "==", "!=")
+}
+
+func (p *parser) parseComparison() (node, error) {
+	return p.parseBinary(p.parseUnary, "<", "<=", ">", ">=")
+}
+
+// parseBinary parses a left-associative chain of the given operators.
+func (p *parser) parseBinary(operand func() (node, error), ops ...string) (node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokenOperator || !slices.Contains(ops, tok.value) {
+			return left, nil
+		}
+
+		p.pos++
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binary{op: tok.value, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return not{operand: operand}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenString:
+		return literal{value: String(tok.value)}, nil
+	case tokenNumber:
+		return parseNumber(tok)
+	case tokenIdent:
+		return p.parseIdent(tok)
+	case tokenOperator:
+		if tok.value == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			return inner, p.expect(")")
+		}
+	case tokenEOF:
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrSyntax)
+	}
+
+	return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, tok.value, tok.pos)
+}
+
+func parseNumber(tok token) (node, error) {
+	if n, err := strconv.ParseFloat(tok.value, 64); err == nil {
+		return literal{value: Number(n)}, nil
+	}
+
+	if n, err := strconv.ParseInt(tok.value, 0, 64); err == nil {
+		return literal{value: Number(float64(n))}, nil
+	}
+
+	return nil, fmt.Errorf("%w: invalid number %q at position %d", ErrSyntax, tok.value, tok.pos)
+}
+
+// parseIdent parses a keyword literal, a function call or a context property.
+func (p *parser) parseIdent(tok token) (node, error) {
+	switch tok.value {
+	case "true":
+		return literal{value: Bool(true)}, nil
+	case "false":
+		return literal{value: Bool(false)}, nil
+	case "null":
+		return literal{value: Null()}, nil
+	}
+
+	if p.accept("(") {
+		return p.parseCall(tok)
+	}
+
+	prop := property{root: tok.value}
+
+	for {
+		switch {
+		case p.accept("."):
+			key := p.next()
+			if key.kind != tokenIdent {
+				return nil, fmt.Errorf("%w: expected property name at position %d", ErrSyntax, key.pos)
+			}
+
+			prop.keys = append(prop.keys, literal{value: String(key.value)})
+		case p.accept("["):
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+
+			prop.keys = append(prop.keys, index)
+		default:
+			return prop, nil
+		}
+	}
+}
+
+// parseCall parses the arguments of a function call whose "(" was consumed.
+func (p *parser) parseCall(name token) (node, error) {
+	fn := strings.ToLower(name.value)
+
+	arity, ok := functionArity[fn]
+	if !ok {
+		return nil, fmt.Errorf("%w: function %s()", ErrUnknown, name.value)
+	}
+
+	if _, ok := statusFunctions[fn]; ok {
+		p.checkStatus = p.checkStatus || fn != "success"
+	}
+
+	var args []node
+
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			args = append(args, arg)
+
+			if p.accept(")") {
+				break
+			}
+
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(args) < arity[0] || (arity[1] >= 0 && len(args) > arity[1]) {
+		return nil, fmt.Errorf("%w: wrong number of arguments to %s()", ErrSyntax, name.value)
+	}
+
+	return call{name: fn, args: args}, nil
+}
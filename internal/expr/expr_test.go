@@ -0,0 +1,189 @@
+package expr_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/expr"
+)
+
+func testContext() expr.Context {
+	return expr.Context{
+		Inputs: map[string]expr.Value{
+			"deploy":      expr.Bool(true),
+			"environment": expr.String("Production"),
+			"replicas":    expr.Number(3),
+		},
+		Github: map[string]expr.Value{
+			"ref":                 expr.String("refs/heads/release/1.2"),
+			"ref_name":            expr.String("release/1.2"),
+			"event_name":          expr.String("workflow_dispatch"),
+			"event.inputs.deploy": expr.String("true"),
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"${{ inputs.deploy }}", true},
+		{"inputs.deploy == true", true},
+		{"inputs.deploy == 'true'", false},
+		{"github.event.inputs.deploy == 'true'", true},
+		{"inputs.environment == 'production'", true},
+		{"inputs.environment != 'staging'", true},
+		{"inputs.replicas >= 2 && inputs.replicas < 5", true},
+		{"inputs.replicas == '3'", true},
+		{"inputs.missing", false},
+		{"inputs.missing == null", true},
+		{"!inputs.deploy || github.ref_name == 'main'", false},
+		{"github.ref == 'refs/heads/main' || startsWith(github.ref_name, 'release/')", true},
+		{"endsWith(github.ref, '1.2')", true},
+		{"contains(inputs.environment, 'PROD')", true},
+		{"format('{0}-{1}', inputs.environment, inputs.replicas) == 'production-3'", true},
+		{"format('{{literal}}') == '{literal}'", true},
+		{"inputs['environment'] == 'production'", true},
+		{"(false || 1) && ''", false},
+		{"always() && !cancelled()", true},
+		{"0x10 == 16 && -1 < 0", true},
+		{"'it''s' == 'IT''S'", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := expr.Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			got, err := e.Evaluate(testContext())
+			if err != nil {
+				t.Fatalf("Evaluate failed: %v", err)
+			}
+
+			if got.Truthy() != tt.want {
+				t.Errorf("got %v (%q), want %v", got.Truthy(), got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluate_Unknown(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"secrets.TOKEN != ''",
+		"vars.DEPLOY == 'true'",
+		"github.sha == 'abc'",
+		"needs.build.outputs.changed == 'true'",
+		"inputs.deploy && github.actor == 'me'",
+	}
+
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			t.Parallel()
+
+			e, err := expr.Parse(expression)
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			if _, err := e.Evaluate(testContext()); !errors.Is(err, expr.ErrUnknown) {
+				t.Errorf("expected ErrUnknown, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEvaluate_ShortCircuitSkipsUnknown(t *testing.T) {
+	t.Parallel()
+
+	e, err := expr.Parse("github.ref_name == 'main' && secrets.TOKEN != ''")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	got, err := e.Evaluate(testContext())
+	if err != nil {
+		t.Fatalf("Evaluate failed: %v", err)
+	}
+
+	if got.Truthy() {
+		t.Error("expected false")
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expression string
+		wantErr    error
+	}{
+		{"inputs.deploy ==", expr.ErrSyntax},
+		{"'unterminated", expr.ErrSyntax},
+		{"(inputs.deploy", expr.ErrSyntax},
+		{"inputs.deploy $ 1", expr.ErrSyntax},
+		{"contains('a')", expr.ErrSyntax},
+		{"toJSON(inputs)", expr.ErrUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := expr.Parse(tt.expression); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExpression_ChecksStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"inputs.deploy":              false,
+		"success() && inputs.deploy": false,
+		"always()":                   true,
+		"failure() || inputs.deploy": true,
+	}
+
+	for expression, want := range tests {
+		e, err := expr.Parse(expression)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", expression, err)
+		}
+
+		if got := e.ChecksStatus(); got != want {
+			t.Errorf("ChecksStatus(%q) = %v, want %v", expression, got, want)
+		}
+	}
+}
+
+func TestValue_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value expr.Value
+		want  string
+	}{
+		{expr.Null(), ""},
+		{expr.Bool(false), "false"},
+		{expr.Number(1.5), "1.5"},
+		{expr.Number(42), "42"},
+		{expr.String("x"), "x"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
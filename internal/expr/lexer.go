@@ -0,0 +1,132 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind classifies a lexed token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+// token is one lexeme of an expression.
+type token struct {
+	value string
+	kind  tokenKind
+	pos   int
+}
+
+// operators lists the punctuation the lexer recognizes, longest first.
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", "[", "]", ".", ","}
+
+// lex splits an expression into tokens.
+func lex(src string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '\'':
+			value, next, err := lexString(src, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: i})
+			i = next
+		case isDigit(c) || (c == '-' && i+1 < len(src) && isDigit(src[i+1])):
+			start := i
+			i++
+
+			for i < len(src) && isNumberChar(src[i]) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenNumber, value: src[start:i], pos: start})
+		case isIdentStart(c):
+			start := i
+
+			for i < len(src) && isIdentChar(src[i]) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenIdent, value: src[start:i], pos: start})
+		default:
+			op := matchOperator(src[i:])
+			if op == "" {
+				return nil, fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, c, i)
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, value: op, pos: i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+// lexString reads a single-quoted string starting at src[start], where a
+// doubled quote is an escaped quote. It returns the value and the index after
+// the closing quote.
+//
+//nolint:gocritic // unnamedResult: mirrors the (token, position) shape of the other lexers
+func lexString(src string, start int) (string, int, error) {
+	var sb strings.Builder
+
+	for i := start + 1; i < len(src); i++ {
+		if src[i] != '\'' {
+			sb.WriteByte(src[i])
+			continue
+		}
+
+		if i+1 < len(src) && src[i+1] == '\'' {
+			sb.WriteByte('\'')
+			i++
+
+			continue
+		}
+
+		return sb.String(), i + 1, nil
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string at position %d", ErrSyntax, start)
+}
+
+func matchOperator(rest string) string {
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isNumberChar accepts the characters of decimal, exponent and hex literals.
+func isNumberChar(c byte) bool {
+	return isDigit(c) || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-' ||
+		c == 'x' || c == 'X' || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func isIdentStart(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
+
+// isIdentChar accepts the characters of property names, which may contain dashes.
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '-'
+}
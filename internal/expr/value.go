@@ -0,0 +1,155 @@
+// Package expr evaluates the subset of GitHub Actions expressions used in job
+// "if:" conditions, so the TUI can preview which jobs a dispatch will run.
+package expr
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of an expression value.
+type Kind int
+
+// Expression value kinds.
+const (
+	KindNull Kind = iota
+	KindBool
+	KindNumber
+	KindString
+)
+
+// Value is the result of evaluating an expression.
+type Value struct {
+	Str  string
+	Num  float64
+	Kind Kind
+	Bool bool
+}
+
+// Null returns the null value.
+func Null() Value {
+	return Value{Kind: KindNull}
+}
+
+// Bool returns a boolean value.
+func Bool(b bool) Value {
+	return Value{Kind: KindBool, Bool: b}
+}
+
+// Number returns a numeric value.
+func Number(n float64) Value {
+	return Value{Kind: KindNumber, Num: n}
+}
+
+// String returns a string value.
+func String(s string) Value {
+	return Value{Kind: KindString, Str: s}
+}
+
+// Truthy reports whether the value counts as true in a condition. Null, false,
+// 0, NaN and the empty string are falsy; everything else is truthy.
+func (v Value) Truthy() bool {
+	switch v.Kind {
+	case KindBool:
+		return v.Bool
+	case KindNumber:
+		return v.Num != 0 && !math.IsNaN(v.Num)
+	case KindString:
+		return v.Str != ""
+	case KindNull:
+	}
+
+	return false
+}
+
+// String converts the value the way format() and string comparisons do.
+func (v Value) String() string {
+	switch v.Kind {
+	case KindBool:
+		return strconv.FormatBool(v.Bool)
+	case KindNumber:
+		return strconv.FormatFloat(v.Num, 'f', -1, 64)
+	case KindString:
+		return v.Str
+	case KindNull:
+	}
+
+	return ""
+}
+
+// number coerces the value for comparisons between different kinds. Strings
+// that are not numbers become NaN, which is never equal to anything.
+func (v Value) number() float64 {
+	switch v.Kind {
+	case KindBool:
+		if v.Bool {
+			return 1
+		}
+
+		return 0
+	case KindNumber:
+		return v.Num
+	case KindString:
+		s := strings.TrimSpace(v.Str)
+		if s == "" {
+			return 0
+		}
+
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return n
+		}
+
+		if n, err := strconv.ParseInt(s, 0, 64); err == nil {
+			return float64(n)
+		}
+
+		return math.NaN()
+	case KindNull:
+	}
+
+	return 0
+}
+
+// equal compares values with GitHub's loose equality: strings ignore case,
+// and values of different kinds are compared as numbers.
+func equal(a, b Value) bool {
+	if a.Kind != b.Kind {
+		return a.number() == b.number()
+	}
+
+	switch a.Kind {
+	case KindBool:
+		return a.Bool == b.Bool
+	case KindNumber:
+		return a.Num == b.Num
+	case KindString:
+		return strings.EqualFold(a.Str, b.Str)
+	case KindNull:
+	}
+
+	return true
+}
+
+// compare orders two values for <, <=, > and >=. The second result is false
+// when the values are not ordered, such as when either is NaN.
+//
+//nolint:gocritic // unnamedResult: the order and whether it exists read clearly at call sites
+func compare(a, b Value) (int, bool) {
+	if a.Kind == KindString && b.Kind == KindString {
+		return strings.Compare(strings.ToLower(a.Str), strings.ToLower(b.Str)), true
+	}
+
+	x, y := a.number(), b.number()
+
+	switch {
+	case math.IsNaN(x) || math.IsNaN(y):
+		return 0, false
+	case x < y:
+		return -1, true
+	case x > y:
+		return 1, true
+	}
+
+	return 0, true
+}
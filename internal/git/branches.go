@@ -112,6 +112,23 @@ func getDefaultBranchWithRunner(ctx context.Context, r CommandRunner) string {
 	return branch
 }
 
+// ListTags returns the repository's tags, most recently created first.
+func ListTags(ctx context.Context) ([]string, error) {
+	return listTagsWithRunner(ctx, runner)
+}
+
+func listTagsWithRunner(ctx context.Context, r CommandRunner) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitCommandTimeout)
+	defer cancel()
+
+	output, err := r.RunCommand(ctx, "tag", "--list", "--sort=-creatordate")
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(output)), nil
+}
+
 // DefaultBranches returns the fallback branch list used when none can be fetched.
 func DefaultBranches() []string {
 	return []string{branchMain, branchMaster, branchDevelop}
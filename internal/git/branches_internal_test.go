@@ -249,6 +249,19 @@ func TestFetchBranches(t *testing.T) {
 	}
 }
 
+func TestListTags(t *testing.T) {
+	t.Parallel()
+
+	tags, err := listTagsWithRunner(context.Background(), &mockCommandRunner{output: []byte("v1.2.0\nv1.1.0\n\nv1.0.0\n")})
+	if err != nil || !reflect.DeepEqual(tags, []string{"v1.2.0", "v1.1.0", "v1.0.0"}) {
+		t.Errorf("listTagsWithRunner() = %v, %v", tags, err)
+	}
+
+	if _, err := listTagsWithRunner(context.Background(), &mockCommandRunner{err: errNotAGitRepository}); err == nil {
+		t.Error("expected error when git fails")
+	}
+}
+
 func TestGetCurrentBranch(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// ErrRefNotFound indicates a branch resolved to neither a remote-tracking nor a local ref.
+var ErrRefNotFound = errors.New("ref not found")
+
+// RefSource reads workflow files as committed at a git ref rather than from
+// the working tree. It implements workflow.Source.
+type RefSource struct {
+	runner CommandRunner
+	ctx    context.Context //nolint:containedctx // Source methods take no context; bound once per discovery
+	ref    string
+	name   string
+	branch string
+}
+
+// NewRefSource resolves branch to a commit, preferring the remote-tracking
+// "origin/<branch>" since that is what GitHub dispatches against, and falls
+// back to the local branch. Neither is fetched first, so the remote-tracking
+// ref is only as fresh as the last `git fetch`.
+func NewRefSource(ctx context.Context, branch string) (*RefSource, error) {
+	return newRefSourceWithRunner(ctx, runner, branch)
+}
+
+func newRefSourceWithRunner(ctx context.Context, r CommandRunner, branch string) (*RefSource, error) {
+	for _, candidate := range []string{"origin/" + branch, branch} {
+		resolveCtx, cancel := context.WithTimeout(ctx, gitCommandTimeout)
+		output, err := r.RunCommand(resolveCtx, "rev-parse", "--verify", "--quiet", candidate+"^{commit}")
+
+		cancel()
+
+		if err == nil {
+			return &RefSource{
+				runner: r, ctx: ctx, ref: strings.TrimSpace(string(output)), name: candidate, branch: branch,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrRefNotFound, branch)
+}
+
+// Ref returns the commit the source reads from.
+func (s *RefSource) Ref() string {
+	return s.ref
+}
+
+// Name returns the ref the branch resolved to, such as "origin/main".
+func (s *RefSource) Name() string {
+	return s.name
+}
+
+// RemoteTracking reports whether the source reads the remote-tracking ref,
+// as of the last fetch, rather than the local branch.
+func (s *RefSource) RemoteTracking() bool {
+	return s.name != s.branch
+}
+
+// List returns the workflow files present at the ref.
+func (s *RefSource) List() ([]string, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, gitCommandTimeout)
+	defer cancel()
+
+	output, err := s.runner.RunCommand(ctx, "ls-tree", "--name-only", s.ref, workflow.WorkflowDir+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for line := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
+		if path := strings.TrimSpace(line); workflow.IsWorkflowPath(path) {
+			files = append(files, path)
+		}
+	}
+
+	return files, nil
+}
+
+// Read returns the contents of path at the ref.
+func (s *RefSource) Read(path string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(s.ctx, gitCommandTimeout)
+	defer cancel()
+
+	return s.runner.RunCommand(ctx, "show", s.ref+":"+path)
+}
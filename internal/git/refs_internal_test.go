@@ -0,0 +1,73 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// scriptedRunner answers git commands by their joined arguments.
+type scriptedRunner map[string]string
+
+func (r scriptedRunner) RunCommand(_ context.Context, args ...string) ([]byte, error) {
+	output, ok := r[strings.Join(args, " ")]
+	if !ok {
+		return nil, errRefDoesNotExist
+	}
+
+	return []byte(output), nil
+}
+
+func TestRefSource(t *testing.T) {
+	t.Parallel()
+
+	r := scriptedRunner{
+		"rev-parse --verify --quiet origin/feature^{commit}": "abc123\n",
+		"ls-tree --name-only abc123 .github/workflows/":      ".github/workflows/deploy.yml\n.github/workflows/notes.md\n",
+		"show abc123:.github/workflows/deploy.yml":           "name: Deploy\n",
+	}
+
+	src, err := newRefSourceWithRunner(context.Background(), r, "feature")
+	if err != nil {
+		t.Fatalf("newRefSourceWithRunner failed: %v", err)
+	}
+
+	if src.Ref() != "abc123" || src.Name() != "origin/feature" || !src.RemoteTracking() {
+		t.Errorf("resolved %q as %q, want abc123 from origin/feature", src.Ref(), src.Name())
+	}
+
+	files, err := src.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+
+	if want := []string{".github/workflows/deploy.yml"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("List() = %v, want %v", files, want)
+	}
+
+	data, err := src.Read(".github/workflows/deploy.yml")
+	if err != nil || string(data) != "name: Deploy\n" {
+		t.Errorf("Read() = %q, %v", data, err)
+	}
+}
+
+func TestRefSource_FallsBackToLocalBranch(t *testing.T) {
+	t.Parallel()
+
+	r := scriptedRunner{"rev-parse --verify --quiet local-only^{commit}": "def456\n"}
+
+	src, err := newRefSourceWithRunner(context.Background(), r, "local-only")
+	if err != nil {
+		t.Fatalf("newRefSourceWithRunner failed: %v", err)
+	}
+
+	if src.Ref() != "def456" || src.RemoteTracking() {
+		t.Errorf("resolved %q as %q, want def456 from the local branch", src.Ref(), src.Name())
+	}
+
+	if _, err := newRefSourceWithRunner(context.Background(), r, "missing"); !errors.Is(err, ErrRefNotFound) {
+		t.Errorf("expected ErrRefNotFound, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"sync"
 
 	"github.com/kyleking/gh-lazydispatch/internal/exec"
 )
@@ -22,6 +23,9 @@ type Client struct {
 	executor exec.CommandExecutor
 	owner    string
 	repo     string
+	// login caches CurrentUser.
+	login string
+	mu    sync.Mutex
 }
 
 // NewClient creates a new GitHub API client for the specified repository.
@@ -106,6 +110,36 @@ func (c *Client) GetLatestRun(workflowName string) (*WorkflowRun, error) {
 	return &runsResp.WorkflowRuns[0], nil
 }
 
+// environmentsPerPage is the page size requested when listing environments,
+// the API's maximum.
+const environmentsPerPage = 100
+
+// ListEnvironments fetches the repository's deployment environments with their
+// protection rules, following the pagination until total_count is reached.
+func (c *Client) ListEnvironments() ([]Environment, error) {
+	var environments []Environment
+
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("repos/%s/%s/environments?per_page=%d&page=%d", c.owner, c.repo, environmentsPerPage, page)
+
+		stdout, stderr, err := c.executor.Execute("gh", "api", path)
+		if err != nil {
+			return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+		}
+
+		var envResp EnvironmentsResponse
+		if err := json.Unmarshal([]byte(stdout), &envResp); err != nil {
+			return nil, fmt.Errorf("failed to parse environments: %w", err)
+		}
+
+		environments = append(environments, envResp.Environments...)
+
+		if len(envResp.Environments) < environmentsPerPage || len(environments) >= envResp.TotalCount {
+			return environments, nil
+		}
+	}
+}
+
 // Owner returns the repository owner.
 func (c *Client) Owner() string {
 	return c.owner
@@ -2,7 +2,9 @@ package github_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -473,3 +475,365 @@ func TestClient_CommandsExecuted(t *testing.T) {
 		t.Errorf("expected 'gh api ...' command, got %v", cmd.Args)
 	}
 }
+
+func TestClient_ListEnvironments(t *testing.T) {
+	t.Parallel()
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/environments?per_page=100&page=1"}, `{
+		"total_count": 2,
+		"environments": [
+			{"id": 1, "name": "staging", "protection_rules": []},
+			{"id": 2, "name": "production", "protection_rules": [
+				{"id": 10, "type": "required_reviewers", "reviewers": [
+					{"type": "User", "reviewer": {"login": "alice"}},
+					{"type": "Team", "reviewer": {"slug": "ops", "name": "Ops"}}
+				]},
+				{"id": 11, "type": "wait_timer", "wait_timer": 30},
+				{"id": 12, "type": "branch_policy"}
+			]}
+		]
+	}`, "", nil)
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	envs, err := client.ListEnvironments()
+	if err != nil {
+		t.Fatalf("ListEnvironments() error = %v", err)
+	}
+
+	if len(envs) != 2 {
+		t.Fatalf("expected 2 environments, got %d", len(envs))
+	}
+
+	if got := envs[0].ProtectionSummary(); got != "" {
+		t.Errorf("staging ProtectionSummary() = %q, want empty", got)
+	}
+
+	want := "reviewers: alice, @ops · wait 30m · branch policy"
+	if got := envs[1].ProtectionSummary(); got != want {
+		t.Errorf("production ProtectionSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_ListEnvironments_APIError(t *testing.T) {
+	t.Parallel()
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/environments?per_page=100&page=1"},
+		"", "HTTP 404: Not Found", exec.ErrMockExitStatus1)
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if _, err := client.ListEnvironments(); err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestClient_ListEnvironments_Paginates(t *testing.T) {
+	t.Parallel()
+
+	page := func(from, count int) string {
+		envs := make([]string, count)
+		for i := range envs {
+			envs[i] = fmt.Sprintf(`{"id": %d, "name": "env-%d"}`, from+i, from+i)
+		}
+
+		return `{"total_count": 101, "environments": [` + strings.Join(envs, ",") + `]}`
+	}
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/environments?per_page=100&page=1"}, page(0, 100), "", nil)
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/environments?per_page=100&page=2"}, page(100, 1), "", nil)
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	envs, err := client.ListEnvironments()
+	if err != nil {
+		t.Fatalf("ListEnvironments() error = %v", err)
+	}
+
+	if len(envs) != 101 {
+		t.Fatalf("expected 101 environments, got %d", len(envs))
+	}
+
+	if envs[100].Name != "env-100" {
+		t.Errorf("last environment = %q, want env-100", envs[100].Name)
+	}
+}
+
+func TestContentsSource(t *testing.T) {
+	t.Parallel()
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/contents/.github/workflows?ref=release%2F1.0"}, `[
+		{"name": "deploy.yml", "path": ".github/workflows/deploy.yml", "type": "file"},
+		{"name": "README.md", "path": ".github/workflows/README.md", "type": "file"},
+		{"name": "shared", "path": ".github/workflows/shared", "type": "dir"}
+	]`, "", nil)
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/contents/.github/workflows/deploy.yml?ref=release%2F1.0"},
+		// base64 of "name: Deploy\n", wrapped as the API does
+		`{"type": "file", "encoding": "base64", "content": "bmFtZTog\nRGVwbG95Cg==\n"}`, "", nil)
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	src := github.NewContentsSource(client, "release/1.0")
+
+	files, err := src.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(files) != 1 || files[0] != ".github/workflows/deploy.yml" {
+		t.Errorf("List() = %v, want only deploy.yml", files)
+	}
+
+	data, err := src.Read(files[0])
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+
+	if string(data) != "name: Deploy\n" {
+		t.Errorf("Read() = %q, want %q", data, "name: Deploy\n")
+	}
+}
+
+func TestClient_GetDefaultBranchAndListBranches(t *testing.T) {
+	t.Parallel()
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo"}, `{"default_branch": "trunk"}`, "", nil)
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/branches?per_page=100"},
+		`[{"name": "trunk"}, {"name": "release"}]`, "", nil)
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	branch, err := client.GetDefaultBranch()
+	if err != nil || branch != "trunk" {
+		t.Errorf("GetDefaultBranch() = %q, %v; want trunk", branch, err)
+	}
+
+	branches, err := client.ListBranches()
+	if err != nil || len(branches) != 2 || branches[1] != "release" {
+		t.Errorf("ListBranches() = %v, %v", branches, err)
+	}
+}
+
+func TestClient_ListTagsAndReleases(t *testing.T) {
+	t.Parallel()
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/tags?per_page=100"},
+		`[{"name": "v1.1.0"}, {"name": "v1.0.0"}]`, "", nil)
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/releases?per_page=100"},
+		`[{"tag_name": "v1.1.0", "name": "Spring", "draft": true}, {"tag_name": "v1.0.0", "prerelease": true}]`, "", nil)
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	tags, err := client.ListTags()
+	if err != nil || len(tags) != 2 || tags[0] != "v1.1.0" {
+		t.Errorf("ListTags() = %v, %v", tags, err)
+	}
+
+	releases, err := client.ListReleases()
+	if err != nil || len(releases) != 2 || !releases[0].Draft || releases[1].TagName != "v1.0.0" || !releases[1].Prerelease {
+		t.Errorf("ListReleases() = %+v, %v", releases, err)
+	}
+}
+
+func TestClient_DispatchWorkflow(t *testing.T) {
+	t.Parallel()
+
+	dispatchArgs := []string{
+		"api", "-X", "POST", "repos/owner/repo/actions/workflows/deploy.yml/dispatches", "--input", "-",
+	}
+
+	tests := []struct {
+		name      string
+		stdout    string
+		ref       string
+		wantRunID int64
+	}{
+		{
+			name:      "run details returned",
+			stdout:    `{"workflow_run_id": 42, "html_url": "https://github.com/owner/repo/actions/runs/42"}`,
+			ref:       "trunk",
+			wantRunID: 42,
+		},
+		{
+			name: "no content on the default branch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockExec := exec.NewMockExecutor()
+			mockExec.AddCommand("gh", []string{"api", "repos/owner/repo"}, `{"default_branch": "trunk"}`, "", nil)
+			mockExec.AddCommand("gh", dispatchArgs, tt.stdout, "", nil)
+
+			client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			before := time.Now()
+
+			resp, err := client.DispatchWorkflow("deploy.yml", github.DispatchPayload{
+				Ref:    tt.ref,
+				Inputs: map[string]any{"target": "prod", "replicas": json.Number("3"), "dry_run": false},
+			})
+			if err != nil {
+				t.Fatalf("DispatchWorkflow failed: %v", err)
+			}
+
+			if resp.RunID != tt.wantRunID || resp.Ref != "trunk" || resp.DispatchedAt.Before(before) {
+				t.Errorf("DispatchWorkflow() = %+v", resp)
+			}
+
+			sent := mockExec.ExecutedCommands[len(mockExec.ExecutedCommands)-1].Stdin
+			want := `{"inputs":{"dry_run":false,"replicas":3,"target":"prod"},"ref":"trunk","return_run_details":true}`
+
+			if sent != want {
+				t.Errorf("request body = %s, want %s", sent, want)
+			}
+		})
+	}
+}
+
+func mockDispatchedRuns(t *testing.T, m *exec.MockExecutor, path string, runs []github.WorkflowRun) {
+	t.Helper()
+
+	respJSON, err := json.Marshal(github.RunsResponse{WorkflowRuns: runs})
+	if err != nil {
+		t.Fatalf("failed to marshal runs: %v", err)
+	}
+
+	m.AddCommand("gh", []string{"api", path}, string(respJSON), "", nil)
+}
+
+func TestClient_FindDispatchedRun(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	runsPath := "repos/owner/repo/actions/workflows/deploy.yml/runs?" +
+		"actor=octocat&branch=main&created=%3E%3D2026-10-01T12%3A00%3A00Z&event=workflow_dispatch&per_page=20"
+	run := func(id int64, login, branch string, created time.Time) github.WorkflowRun {
+		return github.WorkflowRun{
+			ID: id, Event: github.EventWorkflowDispatch, HeadBranch: branch,
+			Actor: github.User{Login: login}, CreatedAt: created,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		runs      []github.WorkflowRun
+		wantRunID int64
+	}{
+		{
+			name: "closest matching run",
+			runs: []github.WorkflowRun{
+				run(4, "octocat", "main", since.Add(5*time.Second)),
+				run(3, "octocat", "main", since.Add(2*time.Second)),
+				run(2, "teammate", "main", since.Add(time.Second)),
+				run(1, "octocat", "main", since.Add(-time.Hour)),
+			},
+			wantRunID: 3,
+		},
+		{
+			name:      "same second as the dispatch",
+			runs:      []github.WorkflowRun{run(5, "octocat", "main", since)},
+			wantRunID: 5,
+		},
+		{
+			name: "earlier dispatch seconds before is skipped",
+			runs: []github.WorkflowRun{
+				run(11, "octocat", "main", since.Add(8*time.Second)),
+				run(10, "octocat", "main", since.Add(-5*time.Second)),
+			},
+			wantRunID: 11,
+		},
+		{
+			name: "no match yet",
+			runs: []github.WorkflowRun{
+				run(6, "octocat", "feature", since.Add(time.Second)),
+				run(7, "teammate", "main", since.Add(time.Second)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockExec := exec.NewMockExecutor()
+			mockExec.AddCommand("gh", []string{"api", "user"}, `{"login": "octocat"}`, "", nil)
+			mockDispatchedRuns(t, mockExec, runsPath, tt.runs)
+
+			client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			got, err := client.FindDispatchedRun("deploy.yml", &github.DispatchResponse{
+				DispatchedAt: since.Add(300 * time.Millisecond), Ref: "main",
+			})
+			if tt.wantRunID == 0 {
+				if !errors.Is(err, github.ErrNoWorkflowRuns) {
+					t.Errorf("expected ErrNoWorkflowRuns, got %v, %v", got, err)
+				}
+
+				return
+			}
+
+			if err != nil || got.ID != tt.wantRunID {
+				t.Errorf("FindDispatchedRun() = %v, %v; want run %d", got, err, tt.wantRunID)
+			}
+		})
+	}
+}
+
+func TestClient_FindDispatchedRun_UnknownActor(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// No "user" response is configured, as with an installation token.
+	mockExec := exec.NewMockExecutor()
+	mockDispatchedRuns(t, mockExec,
+		"repos/owner/repo/actions/workflows/deploy.yml/runs?"+
+			"branch=main&created=%3E%3D2026-10-01T12%3A00%3A00Z&event=workflow_dispatch&per_page=20",
+		[]github.WorkflowRun{{
+			ID: 9, Event: github.EventWorkflowDispatch, HeadBranch: "main",
+			Actor: github.User{Login: "ci-bot"}, CreatedAt: since,
+		}})
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	got, err := client.FindDispatchedRun("deploy.yml", &github.DispatchResponse{DispatchedAt: since, Ref: "main"})
+	if err != nil || got.ID != 9 {
+		t.Errorf("FindDispatchedRun() = %v, %v; want run 9", got, err)
+	}
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// ErrUnsupportedContentEncoding indicates the contents API returned a file in an encoding other than base64.
+var ErrUnsupportedContentEncoding = errors.New("unsupported content encoding")
+
+// contentEncodingBase64 is the only encoding the contents API uses for files under 1 MB.
+const contentEncodingBase64 = "base64"
+
+// GetDefaultBranch fetches the name of the repository's default branch.
+func (c *Client) GetDefaultBranch() (string, error) {
+	path := fmt.Sprintf("repos/%s/%s", c.owner, c.repo)
+
+	stdout, stderr, err := c.executor.Execute("gh", "api", path)
+	if err != nil {
+		return "", fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var repo Repository
+	if err := json.Unmarshal([]byte(stdout), &repo); err != nil {
+		return "", fmt.Errorf("failed to parse repository: %w", err)
+	}
+
+	return repo.DefaultBranch, nil
+}
+
+// ListBranches fetches the names of the repository's branches (first 100).
+func (c *Client) ListBranches() ([]string, error) {
+	path := fmt.Sprintf("repos/%s/%s/branches?per_page=100", c.owner, c.repo)
+
+	stdout, stderr, err := c.executor.Execute("gh", "api", path)
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var branches []Branch
+	if err := json.Unmarshal([]byte(stdout), &branches); err != nil {
+		return nil, fmt.Errorf("failed to parse branches: %w", err)
+	}
+
+	names := make([]string, len(branches))
+	for i, b := range branches {
+		names[i] = b.Name
+	}
+
+	return names, nil
+}
+
+// ListTags fetches the names of the repository's tags (first 100, newest first).
+func (c *Client) ListTags() ([]string, error) {
+	path := fmt.Sprintf("repos/%s/%s/tags?per_page=100", c.owner, c.repo)
+
+	stdout, stderr, err := c.executor.Execute("gh", "api", path)
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var tags []Tag
+	if err := json.Unmarshal([]byte(stdout), &tags); err != nil {
+		return nil, fmt.Errorf("failed to parse tags: %w", err)
+	}
+
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+
+	return names, nil
+}
+
+// ListReleases fetches the repository's releases (first 100, newest first),
+// including drafts when the token can see them.
+func (c *Client) ListReleases() ([]Release, error) {
+	path := fmt.Sprintf("repos/%s/%s/releases?per_page=100", c.owner, c.repo)
+
+	stdout, stderr, err := c.executor.Execute("gh", "api", path)
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var releases []Release
+	if err := json.Unmarshal([]byte(stdout), &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// ListDirectory fetches the entries of a repository directory at ref.
+// An empty ref reads the default branch.
+func (c *Client) ListDirectory(dir, ref string) ([]ContentEntry, error) {
+	stdout, stderr, err := c.executor.Execute("gh", "api", c.contentsPath(dir, ref))
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var entries []ContentEntry
+	if err := json.Unmarshal([]byte(stdout), &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse directory listing: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetFileContent fetches and decodes a repository file at ref.
+// An empty ref reads the default branch.
+func (c *Client) GetFileContent(path, ref string) ([]byte, error) {
+	stdout, stderr, err := c.executor.Execute("gh", "api", c.contentsPath(path, ref))
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var entry ContentEntry
+	if err := json.Unmarshal([]byte(stdout), &entry); err != nil {
+		return nil, fmt.Errorf("failed to parse file content: %w", err)
+	}
+
+	if entry.Encoding != contentEncodingBase64 {
+		return nil, fmt.Errorf("%w: %q for %s", ErrUnsupportedContentEncoding, entry.Encoding, path)
+	}
+
+	// The API wraps base64 content at 60 columns.
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(entry.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	return data, nil
+}
+
+func (c *Client) contentsPath(path, ref string) string {
+	apiPath := fmt.Sprintf("repos/%s/%s/contents/%s", c.owner, c.repo, path)
+	if ref != "" {
+		apiPath += "?ref=" + url.QueryEscape(ref)
+	}
+
+	return apiPath
+}
+
+// ContentsSource reads workflow files through the contents API, for
+// repositories with no local checkout. It implements workflow.Source.
+type ContentsSource struct {
+	client *Client
+	ref    string
+}
+
+// NewContentsSource returns a source reading from ref, or from the default branch if ref is empty.
+func NewContentsSource(client *Client, ref string) *ContentsSource {
+	return &ContentsSource{client: client, ref: ref}
+}
+
+// List returns the workflow files present at the source's ref.
+func (s *ContentsSource) List() ([]string, error) {
+	entries, err := s.client.ListDirectory(workflow.WorkflowDir, s.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, entry := range entries {
+		if entry.Type == contentTypeFile && workflow.IsWorkflowPath(entry.Path) {
+			files = append(files, entry.Path)
+		}
+	}
+
+	return files, nil
+}
+
+// Read returns the contents of path at the source's ref.
+func (s *ContentsSource) Read(path string) ([]byte, error) {
+	return s.client.GetFileContent(path, s.ref)
+}
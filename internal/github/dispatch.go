@@ -0,0 +1,184 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventWorkflowDispatch is the event of runs started through the dispatches endpoint.
+const EventWorkflowDispatch = "workflow_dispatch"
+
+// dispatchRunsPerPage is how many recent runs are checked for a dispatched run.
+const dispatchRunsPerPage = 20
+
+// DispatchPayload is the JSON body of a workflow_dispatch request. Input
+// values are strings, booleans or numbers, as the workflow declares them; an
+// input left out of Inputs takes its default.
+type DispatchPayload struct {
+	Inputs map[string]any `json:"inputs,omitempty"`
+	// Ref is the branch or tag to run on. Empty uses the default branch.
+	Ref string `json:"ref"`
+}
+
+// dispatchBody is the request body DispatchWorkflow sends: the payload, plus
+// the flag asking GitHub to return the started run.
+type dispatchBody struct {
+	DispatchPayload
+
+	ReturnRunDetails bool `json:"return_run_details"`
+}
+
+// DispatchResponse is the result of creating a workflow_dispatch event.
+// RunID is zero when GitHub did not return the run's details, in which case
+// FindDispatchedRun locates it from Ref and DispatchedAt.
+type DispatchResponse struct {
+	DispatchedAt time.Time `json:"-"`
+	RunURL       string    `json:"run_url"`
+	HTMLURL      string    `json:"html_url"`
+	Ref          string    `json:"-"`
+	RunID        int64     `json:"workflow_run_id"`
+}
+
+// DispatchWorkflow creates a workflow_dispatch event for workflow, a file
+// name or numeric ID, sending payload as the request body and asking GitHub
+// to return the ID of the run it starts.
+func (c *Client) DispatchWorkflow(workflow string, payload DispatchPayload) (*DispatchResponse, error) {
+	if payload.Ref == "" {
+		branch, err := c.GetDefaultBranch()
+		if err != nil {
+			return nil, fmt.Errorf("resolving default branch: %w", err)
+		}
+
+		payload.Ref = branch
+	}
+
+	body, err := json.Marshal(dispatchBody{DispatchPayload: payload, ReturnRunDetails: true})
+	if err != nil {
+		return nil, fmt.Errorf("encoding dispatch payload: %w", err)
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/actions/workflows/%s/dispatches", c.owner, c.repo, url.PathEscape(workflow))
+	dispatchedAt := time.Now()
+
+	stdout, stderr, err := c.executor.ExecuteWithStdin(string(body), "gh", "api", "-X", "POST", path, "--input", "-")
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	resp := DispatchResponse{DispatchedAt: dispatchedAt, Ref: payload.Ref}
+
+	// GitHub answers 204 No Content when it does not return run details.
+	if strings.TrimSpace(stdout) != "" {
+		if err := json.Unmarshal([]byte(stdout), &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse dispatch response: %w", err)
+		}
+	}
+
+	return &resp, nil
+}
+
+// CurrentUser returns the login of the authenticated user. The result is
+// cached for the life of the client.
+func (c *Client) CurrentUser() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.login != "" {
+		return c.login, nil
+	}
+
+	stdout, stderr, err := c.executor.Execute("gh", "api", "user")
+	if err != nil {
+		return "", fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(stdout), &user); err != nil {
+		return "", fmt.Errorf("failed to parse user: %w", err)
+	}
+
+	c.login = user.Login
+
+	return c.login, nil
+}
+
+// FindDispatchedRun finds the run that dispatched started: a
+// workflow_dispatch run on its ref, created by the authenticated user no
+// earlier than its DispatchedAt. GitHub records creation times to the second,
+// so DispatchedAt is truncated to match. There is no allowance for clock skew:
+// an earlier run of the same workflow could fall inside it, so a local clock
+// running ahead of GitHub's makes the run unfindable rather than misattributed.
+// When several runs match, the one created closest to DispatchedAt is taken.
+// The actor is not checked if the user cannot be determined, such as with an
+// installation token. It returns ErrNoWorkflowRuns if no run matches yet.
+func (c *Client) FindDispatchedRun(workflow string, dispatched *DispatchResponse) (*WorkflowRun, error) {
+	earliest := dispatched.DispatchedAt.UTC().Truncate(time.Second)
+
+	query := url.Values{}
+	query.Set("created", ">="+earliest.Format(time.RFC3339))
+
+	//nolint:errcheck // best-effort: without a login, runs are matched on event, branch and time only
+	actor, _ := c.CurrentUser()
+	if actor != "" {
+		query.Set("actor", actor)
+	}
+
+	runs, err := c.listDispatchRuns(workflow, dispatched.Ref, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		match *WorkflowRun
+		best  time.Duration
+	)
+
+	for i, run := range runs {
+		if run.Event != EventWorkflowDispatch || run.HeadBranch != dispatched.Ref ||
+			run.CreatedAt.Before(earliest) {
+			continue
+		}
+
+		if actor != "" && run.Actor.Login != actor {
+			continue
+		}
+
+		distance := run.CreatedAt.Sub(earliest)
+		if match == nil || distance < best || (distance == best && run.ID < match.ID) {
+			match, best = &runs[i], distance
+		}
+	}
+
+	if match == nil {
+		return nil, ErrNoWorkflowRuns
+	}
+
+	return match, nil
+}
+
+// listDispatchRuns lists the most recent workflow_dispatch runs of workflow
+// on ref, narrowed by any extra query parameters.
+func (c *Client) listDispatchRuns(workflow, ref string, query url.Values) ([]WorkflowRun, error) {
+	query.Set("event", EventWorkflowDispatch)
+	query.Set("branch", ref)
+	query.Set("per_page", strconv.Itoa(dispatchRunsPerPage))
+
+	path := fmt.Sprintf("repos/%s/%s/actions/workflows/%s/runs?%s",
+		c.owner, c.repo, url.PathEscape(workflow), query.Encode())
+
+	stdout, stderr, err := c.executor.Execute("gh", "api", path)
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %w (stderr: %s)", err, stderr)
+	}
+
+	var runsResp RunsResponse
+	if err := json.Unmarshal([]byte(stdout), &runsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse runs: %w", err)
+	}
+
+	return runsResp.WorkflowRuns, nil
+}
@@ -1,6 +1,10 @@
 package github
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // WorkflowRun represents a GitHub Actions workflow run.
 type WorkflowRun struct {
@@ -11,9 +15,16 @@ type WorkflowRun struct {
 	Conclusion string    `json:"conclusion"`
 	HTMLURL    string    `json:"html_url"`
 	HeadBranch string    `json:"head_branch"`
+	Event      string    `json:"event"`
+	Actor      User      `json:"actor"`
 	ID         int64     `json:"id"`
 }
 
+// User is a GitHub account, such as the actor that triggered a run.
+type User struct {
+	Login string `json:"login"`
+}
+
 // RunStatus constants.
 const (
 	StatusQueued     = "queued"
@@ -68,3 +79,129 @@ type RunsResponse struct {
 	WorkflowRuns []WorkflowRun `json:"workflow_runs"`
 	TotalCount   int           `json:"total_count"`
 }
+
+// Environment represents a deployment environment configured on a repository.
+type Environment struct {
+	Name            string           `json:"name"`
+	HTMLURL         string           `json:"html_url"`
+	ProtectionRules []ProtectionRule `json:"protection_rules"`
+	ID              int64            `json:"id"`
+}
+
+// ProtectionRule represents a single protection rule on an environment.
+type ProtectionRule struct {
+	Type      string     `json:"type"`
+	Reviewers []Reviewer `json:"reviewers"`
+	ID        int64      `json:"id"`
+	WaitTimer int        `json:"wait_timer"`
+}
+
+// Reviewer is a user or team required to approve deployments to an environment.
+type Reviewer struct {
+	Reviewer ReviewerIdentity `json:"reviewer"`
+	Type     string           `json:"type"`
+}
+
+// ReviewerIdentity holds the identifying fields of a user or team reviewer.
+type ReviewerIdentity struct {
+	Login string `json:"login"`
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+}
+
+// EnvironmentsResponse represents the API response for listing environments.
+type EnvironmentsResponse struct {
+	Environments []Environment `json:"environments"`
+	TotalCount   int           `json:"total_count"`
+}
+
+// Protection rule types.
+const (
+	ProtectionRequiredReviewers = "required_reviewers"
+	ProtectionWaitTimer         = "wait_timer"
+	ProtectionBranchPolicy      = "branch_policy"
+)
+
+// RequiredReviewers returns the logins of users and slugs of teams that must
+// approve deployments to the environment.
+func (e Environment) RequiredReviewers() []string {
+	var reviewers []string
+
+	for _, rule := range e.ProtectionRules {
+		if rule.Type != ProtectionRequiredReviewers {
+			continue
+		}
+
+		for _, r := range rule.Reviewers {
+			switch {
+			case r.Reviewer.Login != "":
+				reviewers = append(reviewers, r.Reviewer.Login)
+			case r.Reviewer.Slug != "":
+				reviewers = append(reviewers, "@"+r.Reviewer.Slug)
+			case r.Reviewer.Name != "":
+				reviewers = append(reviewers, r.Reviewer.Name)
+			}
+		}
+	}
+
+	return reviewers
+}
+
+// ProtectionSummary describes the environment's protection rules in one line,
+// e.g. "reviewers: alice, @ops · wait 30m · branch policy". Empty if unprotected.
+func (e Environment) ProtectionSummary() string {
+	var parts []string
+
+	if reviewers := e.RequiredReviewers(); len(reviewers) > 0 {
+		parts = append(parts, "reviewers: "+strings.Join(reviewers, ", "))
+	}
+
+	for _, rule := range e.ProtectionRules {
+		switch rule.Type {
+		case ProtectionWaitTimer:
+			if rule.WaitTimer > 0 {
+				parts = append(parts, fmt.Sprintf("wait %dm", rule.WaitTimer))
+			}
+		case ProtectionBranchPolicy:
+			parts = append(parts, "branch policy")
+		}
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// Repository represents the subset of repository metadata the client reads.
+type Repository struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+// Branch represents a repository branch.
+type Branch struct {
+	Name string `json:"name"`
+}
+
+// Tag represents a repository tag.
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Release represents a repository release.
+type Release struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// contentTypeFile is the ContentEntry type for regular files.
+const contentTypeFile = "file"
+
+// ContentEntry represents a file or directory returned by the contents API.
+// Content and Encoding are only populated when fetching a single file.
+type ContentEntry struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
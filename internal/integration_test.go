@@ -2,6 +2,7 @@ package internal_test
 
 import (
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -10,7 +11,6 @@ import (
 	"github.com/kyleking/gh-lazydispatch/internal/exec"
 	"github.com/kyleking/gh-lazydispatch/internal/github"
 	"github.com/kyleking/gh-lazydispatch/internal/logs"
-	"github.com/kyleking/gh-lazydispatch/internal/runner"
 	"github.com/kyleking/gh-lazydispatch/internal/testutil"
 )
 
@@ -19,17 +19,13 @@ var errMockCommand = errors.New("mock command failed")
 // TestEndToEnd_ChainExecutionWithLogs tests the full chain execution flow
 // including workflow dispatch, status watching, and log retrieval.
 // This covers Phases 1-3: Chain execution, log viewer, and real log fetching.
-//
-//nolint:paralleltest // mutates the package-level runner.SetExecutor mock; cannot run concurrent tests
 func TestEndToEnd_ChainExecutionWithLogs(t *testing.T) {
+	t.Parallel()
+
 	mockExec := exec.NewMockExecutor()
 	setupChainExecutionMocks(mockExec)
-	runner.SetExecutor(mockExec)
 
-	defer runner.SetExecutor(nil)
-
-	client := testutil.NewMockGitHubClient().
-		WithRun(&github.WorkflowRun{ID: 1000, Status: github.StatusCompleted, Conclusion: github.ConclusionSuccess})
+	client := newMockExecClient(t, mockExec)
 	w := testutil.NewMockRunWatcher()
 
 	chainDef := &config.Chain{
@@ -57,12 +53,19 @@ func TestEndToEnd_ChainExecutionWithLogs(t *testing.T) {
 		t.Errorf("chain status: got %v, want %v", state.Status, chain.ChainCompleted)
 	}
 
-	if len(mockExec.ExecutedCommands) != 2 {
-		t.Errorf("executed commands: got %d, want 2", len(mockExec.ExecutedCommands))
+	dispatches := dispatchCommands(mockExec)
+	if len(dispatches) != 2 {
+		t.Fatalf("dispatch commands: got %d, want 2", len(dispatches))
 	}
 
-	testutil.AssertCommand(t, mockExec.ExecutedCommands[0], "gh", "workflow", "run", "ci.yml")
-	testutil.AssertCommand(t, mockExec.ExecutedCommands[1], "gh", "workflow", "run", "deploy.yml")
+	testutil.AssertCommand(t, dispatches[0],
+		"gh", "api", "-X", "POST", "repos/owner/repo/actions/workflows/ci.yml/dispatches")
+	testutil.AssertCommand(t, dispatches[1],
+		"gh", "api", "-X", "POST", "repos/owner/repo/actions/workflows/deploy.yml/dispatches")
+
+	if got := state.StepResults[1].RunID; got != 1002 {
+		t.Errorf("deploy run ID: got %d, want 1002", got)
+	}
 }
 
 // TestEndToEnd_LogFetchingWithGHCLI tests log fetching via mocked gh CLI.
@@ -140,14 +143,8 @@ func TestEndToEnd_FailedRunWithErrorLogs(t *testing.T) {
 }
 
 // TestEndToEnd_WatcherRegistration tests that chain execution registers runs with the watcher.
-//
-//nolint:paralleltest // mutates the package-level runner.SetExecutor mock; cannot run concurrent tests
 func TestEndToEnd_WatcherRegistration(t *testing.T) {
-	mockExec := exec.NewMockExecutor()
-	mockExec.AddCommand("gh", []string{"workflow", "run", "test.yml", "--ref", "main"}, "", "", nil)
-	runner.SetExecutor(mockExec)
-
-	defer runner.SetExecutor(nil)
+	t.Parallel()
 
 	client := testutil.NewMockGitHubClient()
 	w := testutil.NewMockRunWatcher()
@@ -171,31 +168,30 @@ func TestEndToEnd_WatcherRegistration(t *testing.T) {
 }
 
 // TestEndToEnd_ChainFailureHandling tests chain behavior when a step fails.
-//
-//nolint:paralleltest // mutates the package-level runner.SetExecutor mock; cannot run concurrent tests
 func TestEndToEnd_ChainFailureHandling(t *testing.T) {
+	t.Parallel()
+
 	tests := []struct {
-		name          string
-		onFailure     config.FailureAction
-		wantStatus    chain.ChainStatus
-		wantCmdsCount int
+		name              string
+		onFailure         config.FailureAction
+		wantStatus        chain.ChainStatus
+		wantDispatchCount int
 	}{
 		{"abort", config.FailureAbort, chain.ChainFailed, 1},
 		{"continue", config.FailureContinue, chain.ChainCompleted, 2},
 	}
 
 	for _, tt := range tests {
-		//nolint:paralleltest // mutates the package-level runner.SetExecutor mock; cannot run concurrent subtests
 		t.Run(tt.name, func(t *testing.T) {
-			mockExec := exec.NewMockExecutor()
-			mockExec.AddCommand("gh", []string{"workflow", "run", "step1.yml", "--ref", "main"},
-				"", "dispatch failed", errMockCommand)
-			mockExec.AddCommand("gh", []string{"workflow", "run", "step2.yml", "--ref", "main"}, "", "", nil)
-			runner.SetExecutor(mockExec)
+			t.Parallel()
 
-			defer runner.SetExecutor(nil)
+			mockExec := exec.NewMockExecutor()
+			mockExec.AddCommand("gh", []string{
+				"api", "-X", "POST", "repos/owner/repo/actions/workflows/step1.yml/dispatches", "--input", "-",
+			}, "", "HTTP 422: Workflow does not have 'workflow_dispatch' trigger", errMockCommand)
+			mockExec.AddGHWorkflowDispatch("owner", "repo", "step2.yml", 2001)
 
-			client := testutil.NewMockGitHubClient()
+			client := newMockExecClient(t, mockExec)
 			w := testutil.NewMockRunWatcher()
 
 			chainDef := &config.Chain{
@@ -217,8 +213,8 @@ func TestEndToEnd_ChainFailureHandling(t *testing.T) {
 				t.Errorf("status: got %v, want %v", state.Status, tt.wantStatus)
 			}
 
-			if len(mockExec.ExecutedCommands) != tt.wantCmdsCount {
-				t.Errorf("commands: got %d, want %d", len(mockExec.ExecutedCommands), tt.wantCmdsCount)
+			if got := len(dispatchCommands(mockExec)); got != tt.wantDispatchCount {
+				t.Errorf("dispatch commands: got %d, want %d", got, tt.wantDispatchCount)
 			}
 		})
 	}
@@ -226,10 +222,35 @@ func TestEndToEnd_ChainFailureHandling(t *testing.T) {
 
 // Setup helpers
 
+// dispatchCommands returns the workflow dispatch requests mockExec received,
+// leaving out the run lookups around them.
+func dispatchCommands(mockExec *exec.MockExecutor) []exec.ExecutedCommand {
+	var dispatches []exec.ExecutedCommand
+
+	for _, cmd := range mockExec.ExecutedCommands {
+		if slices.Contains(cmd.Args, "POST") {
+			dispatches = append(dispatches, cmd)
+		}
+	}
+
+	return dispatches
+}
+
+// newMockExecClient returns a GitHub client for owner/repo whose gh calls run against mockExec.
+func newMockExecClient(t *testing.T, mockExec *exec.MockExecutor) *github.Client {
+	t.Helper()
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	return client
+}
+
 func setupChainExecutionMocks(m *exec.MockExecutor) {
-	m.AddCommand("gh", []string{"workflow", "run", "ci.yml", "--ref", "main"}, "", "", nil)
-	m.AddCommand("gh",
-		[]string{"workflow", "run", "deploy.yml", "--ref", "main", "-f", "environment=staging"}, "", "", nil)
+	m.AddGHWorkflowDispatch("owner", "repo", "ci.yml", 1001)
+	m.AddGHWorkflowDispatch("owner", "repo", "deploy.yml", 1002)
 }
 
 func setupLogFetchingMocks(t *testing.T, m *exec.MockExecutor) {
@@ -258,7 +279,7 @@ Building project...
 Running tests...
 All tests passed
 ##[endgroup]`
-	m.AddGHRunView(1001, 2001, logOutput)
+	m.AddGHRunView("owner", "repo", 1001, 2001, logOutput)
 }
 
 func setupFailedRunMocks(t *testing.T, m *exec.MockExecutor) {
@@ -283,7 +304,7 @@ Cloning repository...
 ERROR: Build failed
 ##[error]Compilation error in main.go
 ##[endgroup]`
-	m.AddGHRunView(1002, 2002, logOutput)
+	m.AddGHRunView("owner", "repo", 1002, 2002, logOutput)
 }
 
 // TestIntegration_ChainExecutionWithLogViewing tests the full end-to-end flow:
@@ -291,14 +312,10 @@ ERROR: Build failed
 // 2. Wait for completion
 // 3. Retrieve logs for each step's workflow run
 // 4. Verify log content and step results correlation
-//
-//nolint:paralleltest // mutates the package-level runner.SetExecutor mock; cannot run concurrent tests
 func TestIntegration_ChainExecutionWithLogViewing(t *testing.T) {
-	mockExec := exec.NewMockExecutor()
-	runner.SetExecutor(mockExec)
-
-	defer runner.SetExecutor(nil)
+	t.Parallel()
 
+	mockExec := exec.NewMockExecutor()
 	client := setupChainWithLogViewingMocks(t, mockExec)
 	w := testutil.NewMockRunWatcher()
 
@@ -387,7 +404,7 @@ Checking out code...
 Running test suite...
 All tests passed (42 tests)
 ##[endgroup]`
-	mockExec.AddGHRunView(5001, 6001, ciLogs)
+	mockExec.AddGHRunView("owner", "repo", 5001, 6001, ciLogs)
 
 	jobsRespDeploy := github.JobsResponse{
 		Jobs: []github.Job{{
@@ -411,7 +428,7 @@ Checking out code...
 Deploying application to production...
 Deployment successful!
 ##[endgroup]`
-	mockExec.AddGHRunView(5002, 6002, deployLogs)
+	mockExec.AddGHRunView("owner", "repo", 5002, 6002, deployLogs)
 
 	client := testutil.NewMockGitHubClient().
 		WithRun(&github.WorkflowRun{
@@ -422,8 +439,8 @@ Deployment successful!
 			ID: 5002, Name: "Deploy", Status: github.StatusCompleted, Conclusion: github.ConclusionSuccess,
 			HTMLURL: "https://github.com/owner/repo/actions/runs/5002",
 		})
-	client.LatestByWorkflow["ci.yml"] = 5001
-	client.LatestByWorkflow["deploy.yml"] = 5002
+	client.DispatchedByWorkflow["ci.yml"] = 5001
+	client.DispatchedByWorkflow["deploy.yml"] = 5002
 
 	return client
 }
@@ -484,14 +501,10 @@ func checkStepLogsNoErrors(t *testing.T, label string, stepLogs []*logs.StepLogs
 
 // TestIntegration_ChainWithErrorLogs tests log viewing for a chain with error-level logs.
 // This verifies that error logs are properly captured even when steps complete.
-//
-//nolint:paralleltest // mutates the package-level runner.SetExecutor mock; cannot run concurrent tests
 func TestIntegration_ChainWithErrorLogs(t *testing.T) {
-	mockExec := exec.NewMockExecutor()
-	runner.SetExecutor(mockExec)
-
-	defer runner.SetExecutor(nil)
+	t.Parallel()
 
+	mockExec := exec.NewMockExecutor()
 	client := setupChainWithErrorLogsMocks(t, mockExec)
 	w := testutil.NewMockRunWatcher()
 
@@ -573,7 +586,7 @@ func setupChainWithErrorLogsMocks(t *testing.T, mockExec *exec.MockExecutor) *te
 Running tests...
 All tests passed
 ##[endgroup]`
-	mockExec.AddGHRunView(7001, 8001, ciLogs)
+	mockExec.AddGHRunView("owner", "repo", 7001, 8001, ciLogs)
 
 	// Step 2: deploy.yml succeeds but has warnings/errors in logs (runID 7002)
 	mockExec.AddCommand("gh", []string{"workflow", "run", "deploy.yml", "--ref", "main"}, "", "", nil)
@@ -600,7 +613,7 @@ Deploying to production...
 Using fallback configuration
 Deployment successful despite warnings
 ##[endgroup]`
-	mockExec.AddGHRunView(7002, 8002, deployLogs)
+	mockExec.AddGHRunView("owner", "repo", 7002, 8002, deployLogs)
 
 	client := testutil.NewMockGitHubClient().
 		WithRun(&github.WorkflowRun{
@@ -611,8 +624,8 @@ Deployment successful despite warnings
 			ID: 7002, Status: github.StatusCompleted, Conclusion: github.ConclusionSuccess,
 			HTMLURL: "https://github.com/owner/repo/actions/runs/7002",
 		})
-	client.LatestByWorkflow["ci.yml"] = 7001
-	client.LatestByWorkflow["deploy.yml"] = 7002
+	client.DispatchedByWorkflow["ci.yml"] = 7001
+	client.DispatchedByWorkflow["deploy.yml"] = 7002
 
 	return client
 }
@@ -0,0 +1,199 @@
+// Package lint checks workflow_dispatch inputs and lazydispatch validation comments for mistakes.
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// Severity classifies how serious a lint issue is.
+type Severity int
+
+// Issue severities. Only errors make a lint run fail.
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+
+	return "error"
+}
+
+// Issue is a single problem found in a workflow file.
+type Issue struct {
+	Path     string
+	Input    string
+	Message  string
+	Line     int
+	Column   int
+	Severity Severity
+}
+
+func (i Issue) String() string {
+	loc := (&workflow.ParseError{Path: i.Path, Line: i.Line, Column: i.Column}).Location()
+	if i.Input != "" {
+		return fmt.Sprintf("%s: %s: input %q: %s", loc, i.Severity, i.Input, i.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", loc, i.Severity, i.Message)
+}
+
+// Result holds every issue found by a lint run, ordered by location.
+type Result struct {
+	Issues []Issue
+	Files  int
+}
+
+// HasErrors returns true if any issue has error severity.
+func (r Result) HasErrors() bool {
+	return slices.ContainsFunc(r.Issues, func(i Issue) bool { return i.Severity == SeverityError })
+}
+
+// Run lints every workflow file under repoRoot's .github/workflows directory.
+func Run(repoRoot string) (Result, error) {
+	report, err := workflow.Discover(repoRoot)
+	if err != nil {
+		return Result{}, fmt.Errorf("discovering workflows: %w", err)
+	}
+
+	var result Result
+
+	for _, failure := range report.Failures {
+		result.Issues = append(result.Issues, Issue{
+			Path:     failure.Path,
+			Line:     failure.Line,
+			Column:   failure.Column,
+			Message:  failure.Err.Error(),
+			Severity: SeverityError,
+		})
+	}
+
+	for _, files := range [][]workflow.File{report.Dispatchable, report.NonDispatchable} {
+		for _, wf := range files {
+			path := filepath.Join(".github", "workflows", wf.Filename)
+			result.Issues = append(result.Issues, CheckWorkflow(path, wf)...)
+			result.Files++
+		}
+	}
+
+	sort.SliceStable(result.Issues, func(i, j int) bool {
+		a, b := result.Issues[i], result.Issues[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+
+		return a.Message < b.Message
+	})
+
+	return result, nil
+}
+
+// CheckWorkflow lints the dispatch inputs of a single parsed workflow.
+// Malformed validation comments are reported by Discover, not here.
+func CheckWorkflow(path string, wf workflow.File) []Issue {
+	var issues []Issue
+
+	inputs := wf.GetInputs()
+
+	defaults := make(map[string]string, len(inputs))
+	for name, input := range inputs {
+		defaults[name] = input.Default
+	}
+
+	for name, input := range inputs {
+		for _, problem := range checkInput(input, inputs, defaults) {
+			problem.Path = path
+			problem.Input = name
+			problem.Line = input.Line
+			problem.Column = input.Column
+			issues = append(issues, problem)
+		}
+	}
+
+	return issues
+}
+
+// checkInput lints one input. inputs and defaults cover every input of the
+// workflow, for rules that compare against another input.
+func checkInput(input workflow.Input, inputs map[string]workflow.Input, defaults map[string]string) []Issue {
+	var issues []Issue
+
+	inputType := input.InputType()
+
+	if !slices.Contains(workflow.KnownInputTypes, inputType) {
+		issues = append(issues, Issue{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("unknown input type %q (expected one of %v)", inputType, workflow.KnownInputTypes),
+		})
+	}
+
+	if inputType == workflow.InputTypeChoice {
+		issues = append(issues, checkChoice(input)...)
+	}
+
+	if input.Required && input.Default == "" {
+		issues = append(issues, Issue{
+			Severity: SeverityError,
+			Message:  "required input has no default; every dispatch must supply a value",
+		})
+	}
+
+	if input.OptionsProvider != nil && inputType != workflow.InputTypeString {
+		issues = append(issues, Issue{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("options comment is ignored on %s inputs; it only applies to string inputs", inputType),
+		})
+	}
+
+	for _, conflict := range rule.Conflicts(input.ValidationRules) {
+		issues = append(issues, Issue{Severity: SeverityError, Message: "conflicting validation rules: " + conflict})
+	}
+
+	for _, r := range input.ValidationRules {
+		if _, ok := inputs[r.Field]; r.Field != "" && !ok {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("validation rule %s references unknown input %q", r, r.Field),
+			})
+		}
+	}
+
+	if input.Default != "" {
+		if errs := rule.ValidateValue(input.Default, input.ValidationRules, defaults); len(errs) > 0 {
+			issues = append(issues, Issue{
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("default %q fails validation: %s", input.Default, errs[0]),
+			})
+		}
+	}
+
+	return issues
+}
+
+func checkChoice(input workflow.Input) []Issue {
+	if len(input.Options) == 0 {
+		return []Issue{{Severity: SeverityError, Message: "choice input has no options"}}
+	}
+
+	if input.Default != "" && !slices.Contains(input.Options, input.Default) {
+		return []Issue{{
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("default %q is not one of the options %v", input.Default, input.Options),
+		}}
+	}
+
+	return nil
+}
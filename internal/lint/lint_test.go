@@ -0,0 +1,160 @@
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/lint"
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+func writeWorkflow(t *testing.T, root, name, content string) {
+	t.Helper()
+
+	dir := filepath.Join(root, ".github", "workflows")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	writeWorkflow(t, root, "deploy.yml", `name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        type: choice
+        options: [staging, production]
+        default: prod
+      version:
+        required: true
+      replicas:
+        # lazydispatch:validate:range:1-5
+        # lazydispatch:validate:range:10-20
+        type: number
+      region:
+        type: location
+      tag:
+        # lazydispatch:validate:regex:[unclosed
+        type: string
+`)
+	writeWorkflow(t, root, "broken.yml", "on: [\n")
+
+	result, err := lint.Run(root)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !result.HasErrors() {
+		t.Error("expected errors")
+	}
+
+	var out strings.Builder
+	for _, issue := range result.Issues {
+		out.WriteString(issue.String() + "\n")
+	}
+
+	for _, want := range []string{
+		`.github/workflows/broken.yml:1: error:`,
+		`deploy.yml:5:7: error: input "environment": default "prod" is not one of the options`,
+		`deploy.yml:9:7: error: input "version": required input has no default`,
+		`deploy.yml:11:7: error: input "replicas": conflicting validation rules: range rules 1-5 and 10-20`,
+		`deploy.yml:15:7: error: input "region": unknown input type "location"`,
+		`deploy.yml:17:7: error: input "tag": invalid validation comment`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing issue %q in:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRun_CleanRepo(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	writeWorkflow(t, root, "ci.yml", `on:
+  workflow_dispatch:
+    inputs:
+      debug:
+        type: boolean
+        default: false
+`)
+
+	result, err := lint.Run(root)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.Issues) != 0 || result.Files != 1 {
+		t.Errorf("expected 1 clean file, got %d files and issues %v", result.Files, result.Issues)
+	}
+}
+
+func TestCheckWorkflow_ChoiceWithoutOptions(t *testing.T) {
+	t.Parallel()
+
+	wf := workflow.File{On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{
+		Inputs: map[string]workflow.Input{"env": {Type: workflow.InputTypeChoice}},
+	}}}
+
+	issues := lint.CheckWorkflow("wf.yml", wf)
+	if len(issues) != 1 || issues[0].Severity != lint.SeverityError {
+		t.Fatalf("expected one error, got %v", issues)
+	}
+}
+
+func TestCheckWorkflow_CrossFieldRules(t *testing.T) {
+	t.Parallel()
+
+	wf := workflow.File{On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{
+		Inputs: map[string]workflow.Input{
+			"source": {Default: "main"},
+			"target": {Default: "main", ValidationRules: []rule.ValidationRule{
+				{Type: rule.RuleNotEqual, Field: "source"},
+				{Type: rule.RuleRequiredIf, Field: "environment", Pattern: "production"},
+			}},
+		},
+	}}}
+
+	var out strings.Builder
+	for _, issue := range lint.CheckWorkflow("wf.yml", wf) {
+		out.WriteString(issue.String() + "\n")
+	}
+
+	for _, want := range []string{
+		`input "target": validation rule required_if:environment=production references unknown input "environment"`,
+		`input "target": default "main" fails validation: must differ from source (not_equal:source)`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("missing issue %q in:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCheckWorkflow_OptionsOnNonStringInput(t *testing.T) {
+	t.Parallel()
+
+	wf := workflow.File{On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{
+		Inputs: map[string]workflow.Input{"replicas": {
+			Type:            workflow.InputTypeNumber,
+			OptionsProvider: &workflow.OptionsProvider{Kind: workflow.OptionsGitTags},
+		}},
+	}}}
+
+	issues := lint.CheckWorkflow("wf.yml", wf)
+	if len(issues) != 1 || issues[0].Severity != lint.SeverityWarning {
+		t.Fatalf("expected one warning, got %v", issues)
+	}
+}
@@ -77,14 +77,30 @@ func (f *GHFetcher) FetchStepLogsReal(runID int64, workflow string) ([]*StepLogs
 	return allStepLogs, nil
 }
 
+// repository is implemented by clients bound to one repository, such as
+// github.Client. gh run view otherwise reads the working directory's.
+type repository interface {
+	Owner() string
+	Repo() string
+}
+
+// runViewArgs returns the arguments of gh run view --log for runID, naming
+// the client's repository when it has one.
+func (f *GHFetcher) runViewArgs(runID int64, extra ...string) []string {
+	args := append([]string{"run", "view", strconv.FormatInt(runID, 10), "--log"}, extra...)
+
+	if repo, ok := f.client.(repository); ok {
+		args = append(args, "--repo", repo.Owner()+"/"+repo.Repo())
+	}
+
+	return args
+}
+
 // fetchJobLogs uses gh CLI to download logs for a specific job.
 func (f *GHFetcher) fetchJobLogs(runID, jobID int64) (string, error) {
 	// Use gh CLI to view logs
 	// Command: gh run view <run-id> --log --job <job-id>
-	stdout, stderr, err := f.executor.Execute("gh", "run", "view",
-		strconv.FormatInt(runID, 10),
-		"--log",
-		"--job", strconv.FormatInt(jobID, 10))
+	stdout, stderr, err := f.executor.Execute("gh", f.runViewArgs(runID, "--job", strconv.FormatInt(jobID, 10))...)
 	if err != nil {
 		return "", fmt.Errorf("gh command failed: %w (stderr: %s)", err, stderr)
 	}
@@ -171,9 +187,7 @@ func (*GHFetcher) parseJobLogsIntoSteps(
 func (f *GHFetcher) FetchWorkflowLogs(runID int64) (string, error) {
 	// Use gh CLI to view all logs
 	// Command: gh run view <run-id> --log
-	stdout, stderr, err := f.executor.Execute("gh", "run", "view",
-		strconv.FormatInt(runID, 10),
-		"--log")
+	stdout, stderr, err := f.executor.Execute("gh", f.runViewArgs(runID)...)
 	if err != nil {
 		return "", fmt.Errorf("gh command failed: %w (stderr: %s)", err, stderr)
 	}
@@ -56,7 +56,7 @@ func TestIntegration_SuccessfulWorkflowRun(t *testing.T) {
 
 	// Mock gh run view for log fetching
 	logOutput := loadFixture(t, "successful_run.txt")
-	mockExec.AddGHRunView(runID, jobID, logOutput)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, logOutput)
 
 	// Setup: Create GitHub client and GHFetcher with mocks
 	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
@@ -161,7 +161,7 @@ func TestIntegration_FailedWorkflowRun(t *testing.T) {
 
 	// Mock gh run view for log fetching
 	logOutput := loadFixture(t, "failed_run.txt")
-	mockExec.AddGHRunView(runID, jobID, logOutput)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, logOutput)
 
 	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
 	if err != nil {
@@ -240,7 +240,7 @@ func TestIntegration_WorkflowWithWarnings(t *testing.T) {
 
 	// Mock gh run view for log fetching
 	logOutput := loadFixture(t, "run_with_warnings.txt")
-	mockExec.AddGHRunView(runID, jobID, logOutput)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, logOutput)
 
 	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
 	if err != nil {
@@ -305,7 +305,7 @@ func TestIntegration_GHCLIError(t *testing.T) {
 	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/actions/runs/12348/jobs"}, jobsJSON, "", nil)
 
 	// Simulate gh CLI error (e.g., network timeout, auth failure)
-	mockExec.AddGHRunViewError(runID, jobID, "HTTP 401: Bad credentials", exec.ErrMockExitStatus1)
+	mockExec.AddGHRunViewError("owner", "repo", runID, jobID, "HTTP 401: Bad credentials", exec.ErrMockExitStatus1)
 
 	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
 	if err != nil {
@@ -478,7 +478,7 @@ func TestIntegration_MultiJobWorkflowRun(t *testing.T) {
 
 	// Mock logs with multiple steps
 	logOutput := loadFixture(t, "multi_job_run.txt")
-	mockExec.AddGHRunView(runID, jobID, logOutput)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, logOutput)
 
 	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
 	if err != nil {
@@ -559,7 +559,7 @@ func TestIntegration_LogStreaming(t *testing.T) {
 
 	// Poll 1: Initial logs (2 steps partially complete)
 	poll1Logs := loadFixture(t, "streaming_poll_1.txt")
-	mockExec.AddGHRunView(runID, jobID, poll1Logs)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, poll1Logs)
 
 	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
 	if err != nil {
@@ -586,7 +586,7 @@ func TestIntegration_LogStreaming(t *testing.T) {
 
 	// Poll 2: More progress (step 2 now has logs, step 1 has more logs)
 	poll2Logs := loadFixture(t, "streaming_poll_2.txt")
-	mockExec.AddGHRunView(runID, jobID, poll2Logs)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, poll2Logs)
 
 	// Execute: Second poll
 	stepLogs2, err := fetcher.FetchStepLogsReal(runID, "ci.yml")
@@ -617,7 +617,7 @@ func TestIntegration_LogStreaming(t *testing.T) {
 
 	// Poll 3: All steps complete
 	poll3Logs := loadFixture(t, "streaming_poll_3.txt")
-	mockExec.AddGHRunView(runID, jobID, poll3Logs)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, poll3Logs)
 
 	// Execute: Third poll
 	stepLogs3, err := fetcher.FetchStepLogsReal(runID, "ci.yml")
@@ -745,7 +745,7 @@ func TestIntegration_LogStreamer_IncrementalDetection(t *testing.T) {
 		"", nil)
 
 	poll1Logs := loadFixture(t, "streaming_poll_1.txt")
-	mockExec.AddGHRunView(runID, jobID, poll1Logs)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, poll1Logs)
 
 	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
 	if err != nil {
@@ -791,7 +791,7 @@ func TestIntegration_LargeLogFile(t *testing.T) {
 
 	// Generate 10k line log using helper
 	largeLog := testutil.GenerateLargeLogFixture(10000)
-	mockExec.AddGHRunView(runID, jobID, largeLog)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, largeLog)
 
 	// Setup jobs response
 	jobsResp := github.JobsResponse{
@@ -865,7 +865,7 @@ func TestIntegration_UnicodeCharacters(t *testing.T) {
 
 	// Use unicode fixture
 	unicodeLog := testutil.GenerateUnicodeLog()
-	mockExec.AddGHRunView(runID, jobID, unicodeLog)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, unicodeLog)
 
 	// Setup jobs response
 	jobsResp := github.JobsResponse{
@@ -932,7 +932,7 @@ func TestIntegration_ANSIColorCodes(t *testing.T) {
 	mockExec := exec.NewMockExecutor()
 
 	ansiLog := testutil.GenerateANSILog()
-	mockExec.AddGHRunView(runID, jobID, ansiLog)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, ansiLog)
 
 	// Setup jobs response
 	jobsResp := github.JobsResponse{
@@ -1049,7 +1049,7 @@ func TestIntegration_VeryLargeLogFile(t *testing.T) {
 
 	// Generate 50k line log
 	largeLog := testutil.GenerateLargeLogFixture(50000)
-	mockExec.AddGHRunView(runID, jobID, largeLog)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, largeLog)
 
 	// Setup jobs response
 	jobsResp := github.JobsResponse{
@@ -1118,7 +1118,7 @@ func TestIntegration_MixedLogContent(t *testing.T) {
 
 	// Generate mixed content log
 	mixedLog := testutil.GenerateMixedLog(1000)
-	mockExec.AddGHRunView(runID, jobID, mixedLog)
+	mockExec.AddGHRunView("owner", "repo", runID, jobID, mixedLog)
 
 	// Setup jobs response
 	jobsResp := github.JobsResponse{
@@ -0,0 +1,213 @@
+// Package options lists the values of inputs annotated with a
+// "# lazydispatch:options:" comment, from git, the GitHub API or a command.
+package options
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pathpkg "path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyleking/gh-lazydispatch/internal/exec"
+	"github.com/kyleking/gh-lazydispatch/internal/git"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// cacheTTL is how long a fetched option list is reused before fetching again.
+const cacheTTL = 5 * time.Minute
+
+// Errors returned while loading options.
+var (
+	ErrNoOptions           = errors.New("no options found")
+	ErrNoClient            = errors.New("no GitHub client for this repository")
+	ErrCommandInRemoteMode = errors.New("cmd options are not run against a remote repository")
+	ErrCommandsDisabled    = errors.New("cmd options are disabled for this repository")
+)
+
+// CommandRunner runs the command lines of cmd providers.
+type CommandRunner interface {
+	// ExecuteIn runs a command in dir, stopping it when ctx is done.
+	ExecuteIn(ctx context.Context, dir, name string, args ...string) (stdout, stderr string, err error)
+}
+
+// Loader fetches option lists and caches them for cacheTTL. Failed fetches
+// are not cached, so the next edit tries again. It is safe for concurrent use.
+type Loader struct {
+	cache        map[workflow.OptionsProvider]cacheEntry
+	client       *github.Client
+	runner       CommandRunner
+	listTags     func(ctx context.Context) ([]string, error)
+	listBranches func(ctx context.Context) ([]string, error)
+	now          func() time.Time
+	commandDir   string
+	mu           sync.Mutex
+	remote       bool
+}
+
+// cacheEntry is one fetched option list and when it was fetched.
+type cacheEntry struct {
+	fetched time.Time
+	options []string
+}
+
+// NewLoader creates a loader that reads git tags and branches from the local
+// clone, or through client when remote is true. client may be nil, which
+// disables releases. cmd providers stay disabled until AllowCommands.
+func NewLoader(client *github.Client, remote bool) *Loader {
+	return NewLoaderWithRunner(client, remote, exec.NewRealExecutor())
+}
+
+// NewLoaderWithRunner is like NewLoader but runs cmd providers with runner.
+func NewLoaderWithRunner(client *github.Client, remote bool, runner CommandRunner) *Loader {
+	return &Loader{
+		cache:        make(map[workflow.OptionsProvider]cacheEntry),
+		client:       client,
+		runner:       runner,
+		listTags:     git.ListTags,
+		listBranches: git.FetchBranches,
+		now:          time.Now,
+		remote:       remote,
+	}
+}
+
+// AllowCommands lets cmd providers run, from dir. An empty dir disables
+// them again. Commands come from workflow files, so the caller decides
+// whether the repository has opted in.
+func (l *Loader) AllowCommands(dir string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.commandDir = dir
+}
+
+// Load returns the options listed by p, filtered by its glob for the git
+// and release providers. An empty list is ErrNoOptions. git and cmd
+// providers are stopped when ctx is done.
+func (l *Loader) Load(ctx context.Context, p workflow.OptionsProvider) ([]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if entry, ok := l.cache[p]; ok && l.now().Sub(entry.fetched) < cacheTTL {
+		return entry.options, nil
+	}
+
+	options, err := l.fetch(ctx, p)
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", p.Describe(), err)
+	}
+
+	if p.Kind != workflow.OptionsCommand {
+		options = filter(options, p.Arg)
+	}
+
+	if len(options) == 0 {
+		return nil, fmt.Errorf("listing %s: %w", p.Describe(), ErrNoOptions)
+	}
+
+	l.cache[p] = cacheEntry{options: options, fetched: l.now()}
+
+	return options, nil
+}
+
+func (l *Loader) fetch(ctx context.Context, p workflow.OptionsProvider) ([]string, error) {
+	switch p.Kind {
+	case workflow.OptionsGitTags:
+		if l.remote {
+			return l.requireClient((*github.Client).ListTags)
+		}
+
+		return l.listTags(ctx)
+	case workflow.OptionsGitBranches:
+		if l.remote {
+			return l.requireClient((*github.Client).ListBranches)
+		}
+
+		return l.listBranches(ctx)
+	case workflow.OptionsReleases:
+		return l.requireClient(releaseTags)
+	case workflow.OptionsCommand:
+		return l.runCommand(ctx, p.Arg)
+	}
+
+	return nil, fmt.Errorf("%w: %q", workflow.ErrUnknownOptionsProvider, p.Kind)
+}
+
+// requireClient calls list with the loader's client, failing if there is none.
+func (l *Loader) requireClient(list func(*github.Client) ([]string, error)) ([]string, error) {
+	if l.client == nil {
+		return nil, ErrNoClient
+	}
+
+	return list(l.client)
+}
+
+// releaseTags lists the tag names of published releases.
+func releaseTags(client *github.Client) ([]string, error) {
+	releases, err := client.ListReleases()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Load wraps with the provider description
+	}
+
+	var tags []string
+
+	for _, release := range releases {
+		if !release.Draft {
+			tags = append(tags, release.TagName)
+		}
+	}
+
+	return tags, nil
+}
+
+// runCommand runs a cmd provider's command line, split on whitespace, from
+// the directory given to AllowCommands, and returns each non-blank line of
+// its output that is not a # comment. Commands come from the repository's
+// workflow files, so they only run for a local checkout that opted in.
+func (l *Loader) runCommand(ctx context.Context, commandLine string) ([]string, error) {
+	if l.remote {
+		return nil, ErrCommandInRemoteMode
+	}
+
+	if l.commandDir == "" {
+		return nil, ErrCommandsDisabled
+	}
+
+	fields := strings.Fields(commandLine)
+
+	stdout, stderr, err := l.runner.ExecuteIn(ctx, l.commandDir, fields[0], fields[1:]...)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w (stderr: %s)", commandLine, err, strings.TrimSpace(stderr))
+	}
+
+	var options []string
+
+	for line := range strings.Lines(stdout) {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			options = append(options, line)
+		}
+	}
+
+	return options, nil
+}
+
+// filter keeps the options matching pattern, or all of them if pattern is empty.
+func filter(options []string, pattern string) []string {
+	if pattern == "" {
+		return options
+	}
+
+	var matched []string
+
+	for _, option := range options {
+		// The pattern was validated when the workflow was parsed.
+		if ok, err := pathpkg.Match(pattern, option); err == nil && ok {
+			matched = append(matched, option)
+		}
+	}
+
+	return matched
+}
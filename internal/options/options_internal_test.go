@@ -0,0 +1,221 @@
+package options
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kyleking/gh-lazydispatch/internal/exec"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+var errGitFailed = errors.New("git failed")
+
+// newTestLoader returns a loader whose GitHub client and commands run
+// against mockExec, and whose local git tags are tags.
+func newTestLoader(t *testing.T, mockExec *exec.MockExecutor, remote bool, tags ...string) *Loader {
+	t.Helper()
+
+	client, err := github.NewClientWithExecutor("owner/repo", mockExec)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	l := NewLoaderWithRunner(client, remote, mockExec)
+	l.AllowCommands("/repo")
+	l.listTags = func(context.Context) ([]string, error) { return tags, nil }
+	l.listBranches = func(context.Context) ([]string, error) { return []string{"main", "release/1.0"}, nil }
+
+	return l
+}
+
+func TestLoader_Load(t *testing.T) {
+	t.Parallel()
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/releases?per_page=100"},
+		`[{"tag_name": "v2.0.0", "draft": true}, {"tag_name": "v1.1.0"}, {"tag_name": "v1.0.0"}]`, "", nil)
+	mockExec.AddCommand("gh", []string{"api", "repos/owner/repo/tags?per_page=100"},
+		`[{"name": "v9.0.0"}, {"name": "nightly"}]`, "", nil)
+	mockExec.AddCommand("./scripts/list-envs.sh", []string{"--all"}, "# environments\nstaging\n\nproduction\n", "", nil)
+
+	tests := []struct {
+		name     string
+		provider workflow.OptionsProvider
+		want     []string
+		remote   bool
+	}{
+		{
+			name:     "local tags matching a glob",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsGitTags, Arg: "v*"},
+			want:     []string{"v1.1.0", "v1.0.0"},
+		},
+		{
+			name:     "remote tags from the API",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsGitTags},
+			want:     []string{"v9.0.0", "nightly"},
+			remote:   true,
+		},
+		{
+			name:     "branches matching a glob",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsGitBranches, Arg: "release/*"},
+			want:     []string{"release/1.0"},
+		},
+		{
+			name:     "published releases",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsReleases},
+			want:     []string{"v1.1.0", "v1.0.0"},
+		},
+		{
+			name:     "command output lines",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsCommand, Arg: "./scripts/list-envs.sh --all"},
+			want:     []string{"staging", "production"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newTestLoader(t, mockExec, tt.remote, "v1.1.0", "latest", "v1.0.0")
+
+			got, err := l.Load(context.Background(), tt.provider)
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Load() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoader_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		provider workflow.OptionsProvider
+		wantErr  error
+		remote   bool
+	}{
+		{
+			name:     "no tags match",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsGitTags, Arg: "release-*"},
+			wantErr:  ErrNoOptions,
+		},
+		{
+			name:     "command in remote mode",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsCommand, Arg: "./list.sh"},
+			wantErr:  ErrCommandInRemoteMode,
+			remote:   true,
+		},
+		{
+			name:     "command fails",
+			provider: workflow.OptionsProvider{Kind: workflow.OptionsCommand, Arg: "./missing.sh"},
+			wantErr:  exec.ErrMockCommandNotConfigured,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := newTestLoader(t, exec.NewMockExecutor(), tt.remote, "v1.0.0")
+
+			if _, err := l.Load(context.Background(), tt.provider); !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoader_Caches(t *testing.T) {
+	t.Parallel()
+
+	l := newTestLoader(t, exec.NewMockExecutor(), false)
+
+	calls := 0
+	fail := true
+	l.listTags = func(context.Context) ([]string, error) {
+		calls++
+		if fail {
+			return nil, errGitFailed
+		}
+
+		return []string{"v1.0.0"}, nil
+	}
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	provider := workflow.OptionsProvider{Kind: workflow.OptionsGitTags}
+
+	if _, err := l.Load(context.Background(), provider); !errors.Is(err, errGitFailed) {
+		t.Fatalf("expected git failure, got %v", err)
+	}
+
+	fail = false
+
+	for range 2 {
+		if _, err := l.Load(context.Background(), provider); err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("expected a failed fetch to be retried and a good one cached, got %d calls", calls)
+	}
+
+	now = now.Add(cacheTTL)
+
+	if _, err := l.Load(context.Background(), provider); err != nil || calls != 3 {
+		t.Errorf("expected an expired entry to be fetched again, got %d calls, %v", calls, err)
+	}
+}
+
+func TestLoader_ReleasesWithoutClient(t *testing.T) {
+	t.Parallel()
+
+	l := NewLoaderWithRunner(nil, false, exec.NewMockExecutor())
+
+	if _, err := l.Load(context.Background(), workflow.OptionsProvider{Kind: workflow.OptionsReleases}); !errors.Is(err, ErrNoClient) {
+		t.Errorf("expected ErrNoClient, got %v", err)
+	}
+}
+
+func TestLoader_Commands(t *testing.T) {
+	t.Parallel()
+
+	provider := workflow.OptionsProvider{Kind: workflow.OptionsCommand, Arg: "./scripts/list-envs.sh --all"}
+
+	mockExec := exec.NewMockExecutor()
+	mockExec.AddCommand("./scripts/list-envs.sh", []string{"--all"}, "staging\n", "", nil)
+
+	l := NewLoaderWithRunner(nil, false, mockExec)
+
+	if _, err := l.Load(context.Background(), provider); !errors.Is(err, ErrCommandsDisabled) {
+		t.Errorf("expected ErrCommandsDisabled before opting in, got %v", err)
+	}
+
+	l.AllowCommands("/repo")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := l.Load(ctx, provider); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a cancelled command to fail, got %v", err)
+	}
+
+	if got, err := l.Load(context.Background(), provider); err != nil || !slices.Equal(got, []string{"staging"}) {
+		t.Errorf("Load() = %v, %v", got, err)
+	}
+
+	if dir := mockExec.ExecutedCommands[len(mockExec.ExecutedCommands)-1].Dir; dir != "/repo" {
+		t.Errorf("command ran in %q, want /repo", dir)
+	}
+}
@@ -0,0 +1,157 @@
+// Package reload detects edits to workflow files and the lazydispatch config
+// while the TUI is running, so they can be re-parsed without restarting.
+package reload
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// PollInterval is the default interval between filesystem scans.
+const PollInterval = time.Second
+
+// stamp identifies one version of a file; a change to either field counts as an edit.
+type stamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watcher polls the workflow directory and config file of a repository, and
+// any extra files named with Watch, for added, modified and removed files.
+// Scanning is a handful of stat calls, so polling avoids a platform-specific
+// notification dependency.
+type Watcher struct {
+	files map[string]stamp
+	root  string
+	extra []string
+	mu    sync.Mutex
+}
+
+// NewWatcher returns a watcher for the repository at root, with the current
+// files as its baseline.
+func NewWatcher(root string) *Watcher {
+	return &Watcher{root: root, files: scan(root, nil)}
+}
+
+// Watch replaces the extra repository-relative files polled alongside the
+// workflows and config, such as the values files of oneof-file rules. Files
+// newly watched join the baseline as they are now, so they are reported only
+// once edited.
+func (w *Watcher) Watch(paths []string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.extra = slices.Clone(paths)
+	current := scan(w.root, w.extra)
+
+	for path := range w.files {
+		if !isScanned(path) && !slices.Contains(w.extra, path) {
+			delete(w.files, path)
+		}
+	}
+
+	for path, s := range current {
+		if _, ok := w.files[path]; !ok && !isScanned(path) {
+			w.files[path] = s
+		}
+	}
+}
+
+// Root returns the repository root being watched.
+func (w *Watcher) Root() string {
+	return w.root
+}
+
+// Poll returns the repository-relative paths added, modified or removed since
+// the previous call, sorted, and makes the current state the new baseline.
+func (w *Watcher) Poll() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current := scan(w.root, w.extra)
+
+	var changed []string
+
+	for path, s := range current {
+		if prev, ok := w.files[path]; !ok || !prev.modTime.Equal(s.modTime) || prev.size != s.size {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range w.files {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	w.files = current
+
+	sort.Strings(changed)
+
+	return changed
+}
+
+// IsConfigPath returns true if path is the lazydispatch config file.
+func IsConfigPath(path string) bool {
+	return filepath.ToSlash(path) == config.ConfigFilename
+}
+
+// IsWorkflowPath returns true if path is a workflow file.
+func IsWorkflowPath(path string) bool {
+	dir, file := filepath.Split(filepath.ToSlash(path))
+	ext := filepath.Ext(file)
+
+	return dir == workflow.WorkflowDir+"/" && (ext == ".yml" || ext == ".yaml")
+}
+
+// isScanned returns true if path is one of the files scan always stats.
+func isScanned(path string) bool {
+	return IsConfigPath(path) || IsWorkflowPath(path)
+}
+
+// scan stats the workflow files and config file under root, and the extra
+// repository-relative paths. Unreadable files are treated as absent.
+func scan(root string, extra []string) map[string]stamp {
+	files := make(map[string]stamp)
+
+	patterns := []string{
+		filepath.Join(root, filepath.FromSlash(workflow.WorkflowDir), "*.yml"),
+		filepath.Join(root, filepath.FromSlash(workflow.WorkflowDir), "*.yaml"),
+		filepath.Join(root, filepath.FromSlash(config.ConfigFilename)),
+	}
+
+	var matches []string
+
+	for _, pattern := range patterns {
+		//nolint:errcheck // patterns are fixed and well-formed; Glob only errors on bad patterns
+		found, _ := filepath.Glob(pattern)
+		matches = append(matches, found...)
+	}
+
+	for _, path := range extra {
+		matches = append(matches, filepath.Join(root, filepath.FromSlash(path)))
+	}
+
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		rel, err := filepath.Rel(root, match)
+		if err != nil {
+			continue
+		}
+
+		files[filepath.ToSlash(rel)] = stamp{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return files
+}
@@ -0,0 +1,110 @@
+package reload_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/reload"
+)
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+
+	path := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".github/workflows/deploy.yml", "name: Deploy\n")
+	writeFile(t, root, ".github/workflows/old.yml", "name: Old\n")
+
+	w := reload.NewWatcher(root)
+
+	if changed := w.Poll(); len(changed) != 0 {
+		t.Fatalf("expected no changes before edits, got %v", changed)
+	}
+
+	writeFile(t, root, ".github/workflows/deploy.yml", "name: Deploy to production\n")
+	writeFile(t, root, ".github/lazydispatch.yml", "version: 1\n")
+	writeFile(t, root, ".github/workflows/notes.md", "ignored\n")
+
+	if err := os.Remove(filepath.Join(root, ".github", "workflows", "old.yml")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{".github/lazydispatch.yml", ".github/workflows/deploy.yml", ".github/workflows/old.yml"}
+	if changed := w.Poll(); !reflect.DeepEqual(changed, want) {
+		t.Errorf("Poll() = %v, want %v", changed, want)
+	}
+
+	if changed := w.Poll(); len(changed) != 0 {
+		t.Errorf("expected changes to be reported once, got %v", changed)
+	}
+}
+
+func TestIsConfigPath(t *testing.T) {
+	t.Parallel()
+
+	if !reload.IsConfigPath(".github/lazydispatch.yml") {
+		t.Error("expected lazydispatch.yml to be the config path")
+	}
+
+	if reload.IsConfigPath(".github/workflows/deploy.yml") {
+		t.Error("expected a workflow file not to be the config path")
+	}
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeFile(t, root, ".github/workflows/deploy.yml", "name: Deploy\n")
+	writeFile(t, root, "deploy/regions.txt", "us-east-1\n")
+
+	w := reload.NewWatcher(root)
+	w.Watch([]string{"deploy/regions.txt"})
+
+	if changed := w.Poll(); len(changed) != 0 {
+		t.Fatalf("expected a newly watched file not to count as changed, got %v", changed)
+	}
+
+	writeFile(t, root, "deploy/regions.txt", "us-east-1\neu-west-1\n")
+
+	if changed := w.Poll(); !reflect.DeepEqual(changed, []string{"deploy/regions.txt"}) {
+		t.Errorf("Poll() = %v, want the edited values file", changed)
+	}
+
+	w.Watch(nil)
+	writeFile(t, root, "deploy/regions.txt", "eu-west-1\n")
+
+	if changed := w.Poll(); len(changed) != 0 {
+		t.Errorf("expected an unwatched file to be ignored, got %v", changed)
+	}
+}
+
+func TestIsWorkflowPath(t *testing.T) {
+	t.Parallel()
+
+	for path, want := range map[string]bool{
+		".github/workflows/deploy.yml":  true,
+		".github/workflows/deploy.yaml": true,
+		".github/workflows/notes.md":    false,
+		".github/lazydispatch.yml":      false,
+		"deploy/regions.txt":            false,
+	} {
+		if got := reload.IsWorkflowPath(path); got != want {
+			t.Errorf("IsWorkflowPath(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
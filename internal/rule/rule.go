@@ -2,11 +2,16 @@
 package rule
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Type represents the type of validation rule.
@@ -20,14 +25,32 @@ const (
 	RulePrefix
 	RuleSuffix
 	RuleLength
+	RuleSemver
+	RuleURL
+	RuleJSON
+	RuleDate
+	RuleOneOfFile
+	RuleRequiredIf
+	RuleNotEqual
 )
 
 // ValidationRule represents a single validation rule parsed from YAML comments.
+// Pattern holds the rule's argument: the regex, prefix or suffix, the semver
+// constraint, the date layout, the allowed values file, or the value Field
+// must have for required_if.
 type ValidationRule struct {
-	Pattern string
-	Type    Type
-	Min     int
-	Max     int
+	// Values holds the allowed values of a oneof-file rule once loaded with
+	// LoadAllowedValues.
+	Values []string
+	// ValuesErr is why LoadAllowedValues could not read a oneof-file rule's
+	// values file, reported whenever a value is checked against the rule.
+	ValuesErr string
+	Pattern   string
+	// Field names the other input a cross-field rule compares against.
+	Field string
+	Type  Type
+	Min   int
+	Max   int
 }
 
 const validationPrefix = "lazydispatch:validate:"
@@ -39,11 +62,22 @@ var (
 	ErrSuffixRuleMissingValue  = errors.New("suffix rule requires a value")
 	ErrInvalidRangeFormat      = errors.New("expected format: min-max")
 	ErrRangeMinGreaterThanMax  = errors.New("min must be less than or equal to max")
+	ErrUnknownRuleType         = errors.New("unknown rule type")
+	ErrOneOfFileMissingPath    = errors.New("oneof-file rule requires a path")
+	ErrOneOfFileOutsideRepo    = errors.New("oneof-file path must be relative to the repository root and stay inside it")
+	ErrInvalidDateLayout       = errors.New("date layout has no date or time elements")
+	ErrRequiredIfFormat        = errors.New("expected format: required_if:other=value")
+	ErrNotEqualMissingField    = errors.New("not_equal rule requires an input name")
+	ErrInvalidSemverConstraint = errors.New("invalid semver constraint")
 )
 
 // ruleSpecMaxParts caps splitting "type:value" into at most a type and a value.
 const ruleSpecMaxParts = 2
 
+// referenceTime is formatted with a date rule's layout to check it has
+// elements. Every field differs from Go's layout reference time.
+var referenceTime = time.Date(2001, time.November, 12, 13, 14, 15, 0, time.UTC)
+
 // rangeParts is the expected number of "min-max" segments in a range spec.
 const rangeParts = 2
 
@@ -58,8 +92,29 @@ func ParseValidationComment(comment string) (*ValidationRule, bool, error) {
 		return nil, false, nil
 	}
 
-	ruleSpec := strings.TrimPrefix(comment, validationPrefix)
+	return parseRuleSpec(strings.TrimPrefix(comment, validationPrefix))
+}
+
+// ParseRuleSpec parses a rule written as "type:value", the part of a
+// validation comment after its prefix, such as "regex:^v\d+" or "range:1-10".
+// Unlike comments, an unknown rule type is an error.
+func ParseRuleSpec(spec string) (*ValidationRule, error) {
+	rule, ok, err := parseRuleSpec(strings.TrimSpace(spec))
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRuleType, spec)
+	}
+
+	return rule, nil
+}
 
+// parseRuleSpec parses "type:value"; ok is false for unknown rule types.
+//
+//nolint:gocritic // unnamedResult: mirrors ParseValidationComment's (rule, ok, err) shape
+func parseRuleSpec(ruleSpec string) (*ValidationRule, bool, error) {
 	parts := strings.SplitN(ruleSpec, ":", ruleSpecMaxParts)
 	if len(parts) == 0 {
 		return nil, false, nil
@@ -85,6 +140,20 @@ func ParseValidationComment(comment string) (*ValidationRule, bool, error) {
 		return parseSuffixRule(ruleValue)
 	case "length":
 		return parseLengthRule(ruleValue)
+	case "semver":
+		return parseSemverRule(ruleValue)
+	case "url":
+		return &ValidationRule{Type: RuleURL}, true, nil
+	case "json":
+		return &ValidationRule{Type: RuleJSON}, true, nil
+	case "date":
+		return parseDateRule(ruleValue)
+	case "oneof-file":
+		return parseOneOfFileRule(ruleValue)
+	case "required_if":
+		return parseRequiredIfRule(ruleValue)
+	case "not_equal":
+		return parseNotEqualRule(ruleValue)
 	default:
 		return nil, false, nil
 	}
@@ -136,6 +205,63 @@ func parseLengthRule(ruleValue string) (*ValidationRule, bool, error) {
 	return &ValidationRule{Type: RuleLength, Min: minVal, Max: maxVal}, true, nil
 }
 
+func parseSemverRule(ruleValue string) (*ValidationRule, bool, error) {
+	constraint := strings.TrimSpace(ruleValue)
+	if _, err := parseConstraint(constraint); err != nil {
+		return nil, false, err
+	}
+
+	return &ValidationRule{Type: RuleSemver, Pattern: constraint}, true, nil
+}
+
+func parseDateRule(ruleValue string) (*ValidationRule, bool, error) {
+	layout := ruleValue
+	if layout == "" {
+		layout = time.DateOnly
+	}
+
+	// A layout without elements formats every time as itself, so it would
+	// only accept its own literal text.
+	if referenceTime.Format(layout) == layout {
+		return nil, false, fmt.Errorf("%w: %q", ErrInvalidDateLayout, layout)
+	}
+
+	return &ValidationRule{Type: RuleDate, Pattern: layout}, true, nil
+}
+
+func parseOneOfFileRule(ruleValue string) (*ValidationRule, bool, error) {
+	path := strings.TrimSpace(ruleValue)
+	if path == "" {
+		return nil, false, ErrOneOfFileMissingPath
+	}
+
+	if !filepath.IsLocal(filepath.FromSlash(path)) {
+		return nil, false, fmt.Errorf("%w: %q", ErrOneOfFileOutsideRepo, path)
+	}
+
+	return &ValidationRule{Type: RuleOneOfFile, Pattern: path}, true, nil
+}
+
+func parseRequiredIfRule(ruleValue string) (*ValidationRule, bool, error) {
+	field, value, ok := strings.Cut(ruleValue, "=")
+	field = strings.TrimSpace(field)
+
+	if !ok || field == "" {
+		return nil, false, ErrRequiredIfFormat
+	}
+
+	return &ValidationRule{Type: RuleRequiredIf, Field: field, Pattern: strings.TrimSpace(value)}, true, nil
+}
+
+func parseNotEqualRule(ruleValue string) (*ValidationRule, bool, error) {
+	field := strings.TrimSpace(ruleValue)
+	if field == "" {
+		return nil, false, ErrNotEqualMissingField
+	}
+
+	return &ValidationRule{Type: RuleNotEqual, Field: field}, true, nil
+}
+
 // ParseValidationComments parses multiple comment lines and returns all valid rules.
 func ParseValidationComments(comments []string) ([]ValidationRule, error) {
 	var rules []ValidationRule
@@ -154,21 +280,65 @@ func ParseValidationComments(comments []string) ([]ValidationRule, error) {
 	return rules, nil
 }
 
-// ValidateValue validates a value against a set of rules.
-// Returns a slice of error messages for any failed validations.
-func ValidateValue(value string, rules []ValidationRule) []string {
+// LoadAllowedValues returns rules with the Values of each oneof-file rule read
+// through read, which resolves repository-relative paths. Each non-blank line
+// of the file is one allowed value; lines starting with # are comments. rules
+// itself is not modified. A file that cannot be read leaves its rule without
+// values and with the reason in ValuesErr, and is also reported in the
+// returned error.
+func LoadAllowedValues(rules []ValidationRule, read func(path string) ([]byte, error)) ([]ValidationRule, error) {
+	if !slices.ContainsFunc(rules, func(r ValidationRule) bool { return r.Type == RuleOneOfFile }) {
+		return rules, nil
+	}
+
+	loaded := slices.Clone(rules)
+
+	var errs []error
+
+	for i, r := range loaded {
+		if r.Type != RuleOneOfFile {
+			continue
+		}
+
+		data, err := read(r.Pattern)
+		if err != nil {
+			err = fmt.Errorf("reading allowed values from %s: %w", r.Pattern, err)
+			loaded[i].ValuesErr = err.Error()
+			errs = append(errs, err)
+
+			continue
+		}
+
+		values := []string{}
+
+		for line := range strings.Lines(string(data)) {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				values = append(values, line)
+			}
+		}
+
+		loaded[i].Values = values
+	}
+
+	return loaded, errors.Join(errs...)
+}
+
+// ValidateValue validates a value against a set of rules. inputs holds the
+// current value of every input, for rules that compare against another input.
+// Returns one message per failed rule, naming the rule that failed.
+func ValidateValue(value string, rules []ValidationRule, inputs map[string]string) []string {
 	var validationErrs []string
 
 	for _, r := range rules {
-		if errMsg := validateRule(value, r); errMsg != "" {
-			validationErrs = append(validationErrs, errMsg)
+		if errMsg := validateRule(value, r, inputs); errMsg != "" {
+			validationErrs = append(validationErrs, fmt.Sprintf("%s (%s)", errMsg, r))
 		}
 	}
 
 	return validationErrs
 }
 
-func validateRule(value string, r ValidationRule) string {
+func validateRule(value string, r ValidationRule, inputs map[string]string) string {
 	switch r.Type {
 	case RuleRequired:
 		return validateRequiredRule(value)
@@ -182,6 +352,24 @@ func validateRule(value string, r ValidationRule) string {
 		return validateSuffixRule(value, r)
 	case RuleLength:
 		return validateLengthRule(value, r)
+	case RuleSemver:
+		return validateSemverRule(value, r)
+	case RuleURL:
+		return validateURLRule(value)
+	case RuleJSON:
+		return validateJSONRule(value)
+	case RuleDate:
+		return validateDateRule(value, r)
+	case RuleOneOfFile:
+		return validateOneOfFileRule(value, r)
+	case RuleRequiredIf:
+		if inputs[r.Field] == r.Pattern && strings.TrimSpace(value) == "" {
+			return fmt.Sprintf("value is required when %s is %q", r.Field, r.Pattern)
+		}
+	case RuleNotEqual:
+		if value != "" && value == inputs[r.Field] {
+			return "must differ from " + r.Field
+		}
 	}
 
 	return ""
@@ -213,12 +401,12 @@ func validateRangeRule(value string, r ValidationRule) string {
 		return ""
 	}
 
-	num, err := strconv.Atoi(value)
+	num, err := strconv.ParseFloat(value, 64)
 	if err != nil {
 		return "must be a number"
 	}
 
-	if num < r.Min || num > r.Max {
+	if num < float64(r.Min) || num > float64(r.Max) {
 		return fmt.Sprintf("must be between %d and %d", r.Min, r.Max)
 	}
 
@@ -250,6 +438,72 @@ func validateLengthRule(value string, r ValidationRule) string {
 	return ""
 }
 
+func validateSemverRule(value string, r ValidationRule) string {
+	if value == "" {
+		return ""
+	}
+
+	version, ok := parseSemver(value)
+	if !ok {
+		return "must be a semantic version such as 1.2.3"
+	}
+
+	// The constraint was checked when the rule was parsed.
+	constraint, err := parseConstraint(r.Pattern)
+	if err == nil && !constraint.allows(version) {
+		return "must satisfy " + r.Pattern
+	}
+
+	return ""
+}
+
+func validateURLRule(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	if u, err := url.ParseRequestURI(value); err != nil || u.Scheme == "" || u.Host == "" {
+		return "must be an absolute URL"
+	}
+
+	return ""
+}
+
+func validateJSONRule(value string) string {
+	if value != "" && !json.Valid([]byte(value)) {
+		return "must be valid JSON"
+	}
+
+	return ""
+}
+
+func validateDateRule(value string, r ValidationRule) string {
+	if value == "" {
+		return ""
+	}
+
+	if _, err := time.Parse(r.Pattern, value); err != nil {
+		return "must be a date in the format " + r.Pattern
+	}
+
+	return ""
+}
+
+func validateOneOfFileRule(value string, r ValidationRule) string {
+	switch {
+	case value == "":
+		return ""
+	case r.ValuesErr != "":
+		return r.ValuesErr
+	case r.Values == nil:
+		return "allowed values from " + r.Pattern + " were not loaded"
+	case !slices.Contains(r.Values, value):
+		return "must be one of the values in " + r.Pattern
+	}
+
+	return ""
+}
+
 // parseRange returns (min, max, err).
 //
 //nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
@@ -275,3 +529,117 @@ func parseRange(s string) (int, int, error) {
 
 	return minVal, maxVal, nil
 }
+
+// Conflicts reports pairs of rules that no single value can satisfy together,
+// such as non-overlapping ranges or incompatible prefixes.
+// Returns a human-readable message per conflicting pair.
+func Conflicts(rules []ValidationRule) []string {
+	var conflicts []string
+
+	for i := range rules {
+		for j := i + 1; j < len(rules); j++ {
+			if msg := conflictBetween(rules[i], rules[j]); msg != "" {
+				conflicts = append(conflicts, msg)
+			}
+		}
+	}
+
+	return conflicts
+}
+
+func conflictBetween(a, b ValidationRule) string {
+	if a.Type != b.Type {
+		if a.Type == RuleLength {
+			a, b = b, a
+		}
+
+		return lengthConflict(a, b)
+	}
+
+	switch a.Type {
+	case RuleRange, RuleLength:
+		if a.Max < b.Min || b.Max < a.Min {
+			return fmt.Sprintf("%s rules %d-%d and %d-%d do not overlap", a.Type, a.Min, a.Max, b.Min, b.Max)
+		}
+	case RulePrefix:
+		if !strings.HasPrefix(a.Pattern, b.Pattern) && !strings.HasPrefix(b.Pattern, a.Pattern) {
+			return fmt.Sprintf("prefix rules %q and %q cannot both match", a.Pattern, b.Pattern)
+		}
+	case RuleSuffix:
+		if !strings.HasSuffix(a.Pattern, b.Pattern) && !strings.HasSuffix(b.Pattern, a.Pattern) {
+			return fmt.Sprintf("suffix rules %q and %q cannot both match", a.Pattern, b.Pattern)
+		}
+	case RuleRegex, RuleRequired, RuleSemver, RuleURL, RuleJSON, RuleDate, RuleOneOfFile, RuleRequiredIf, RuleNotEqual:
+	}
+
+	return ""
+}
+
+// lengthConflict reports a prefix or suffix rule longer than a length rule's maximum.
+func lengthConflict(other, length ValidationRule) string {
+	if length.Type != RuleLength {
+		return ""
+	}
+
+	if (other.Type == RulePrefix || other.Type == RuleSuffix) && len(other.Pattern) > length.Max {
+		return fmt.Sprintf("%s %q is longer than maximum length %d", other.Type, other.Pattern, length.Max)
+	}
+
+	return ""
+}
+
+// String returns the rule type's name as written in validation comments.
+func (t Type) String() string {
+	switch t {
+	case RuleRegex:
+		return "regex"
+	case RuleRange:
+		return "range"
+	case RuleRequired:
+		return "required"
+	case RulePrefix:
+		return "prefix"
+	case RuleSuffix:
+		return "suffix"
+	case RuleLength:
+		return "length"
+	case RuleSemver:
+		return "semver"
+	case RuleURL:
+		return "url"
+	case RuleJSON:
+		return "json"
+	case RuleDate:
+		return "date"
+	case RuleOneOfFile:
+		return "oneof-file"
+	case RuleRequiredIf:
+		return "required_if"
+	case RuleNotEqual:
+		return "not_equal"
+	}
+
+	return "unknown"
+}
+
+// String returns the rule as written in a validation comment, without the
+// prefix, such as "range:1-10" or "required_if:env=production".
+func (r ValidationRule) String() string {
+	switch r.Type {
+	case RuleRange, RuleLength:
+		return fmt.Sprintf("%s:%d-%d", r.Type, r.Min, r.Max)
+	case RuleRequired, RuleURL, RuleJSON:
+		return r.Type.String()
+	case RuleSemver:
+		if r.Pattern == "" {
+			return r.Type.String()
+		}
+	case RuleRequiredIf:
+		return fmt.Sprintf("%s:%s=%s", r.Type, r.Field, r.Pattern)
+	case RuleNotEqual:
+		return r.Type.String() + ":" + r.Field
+	case RuleRegex, RulePrefix, RuleSuffix, RuleDate, RuleOneOfFile:
+	}
+
+	return r.Type.String() + ":" + r.Pattern
+}
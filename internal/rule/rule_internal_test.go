@@ -1,6 +1,10 @@
 package rule
 
 import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -180,6 +184,7 @@ func TestParseValidationComments(t *testing.T) {
 // validateValueCase is a single ValidateValue table-test case, shared across
 // the TestValidateValue_* functions split by rule type.
 type validateValueCase struct {
+	inputs     map[string]string
 	name       string
 	value      string
 	rules      []ValidationRule
@@ -194,7 +199,7 @@ func runValidateValueCases(t *testing.T, tests []validateValueCase) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			errors := ValidateValue(tt.value, tt.rules)
+			errors := ValidateValue(tt.value, tt.rules, tt.inputs)
 
 			if len(errors) != tt.wantErrors {
 				t.Errorf("ValidateValue() errors = %d, want %d; errors: %v", len(errors), tt.wantErrors, errors)
@@ -264,6 +269,14 @@ func TestValidateValue_Range(t *testing.T) {
 			name: "range empty value", value: "",
 			rules: []ValidationRule{{Type: RuleRange, Min: 1, Max: 100}}, wantErrors: 0,
 		},
+		{
+			name: "range decimal within bounds", value: "2.5",
+			rules: []ValidationRule{{Type: RuleRange, Min: 1, Max: 3}}, wantErrors: 0,
+		},
+		{
+			name: "range decimal above max", value: "3.5",
+			rules: []ValidationRule{{Type: RuleRange, Min: 1, Max: 3}}, wantErrors: 1,
+		},
 	})
 }
 
@@ -408,3 +421,252 @@ func TestParseRange(t *testing.T) {
 		})
 	}
 }
+
+func TestConflicts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		rules []ValidationRule
+		want  int
+	}{
+		{"no rules", nil, 0},
+		{"overlapping ranges", []ValidationRule{{Type: RuleRange, Min: 1, Max: 10}, {Type: RuleRange, Min: 5, Max: 20}}, 0},
+		{"disjoint ranges", []ValidationRule{{Type: RuleRange, Min: 1, Max: 5}, {Type: RuleRange, Min: 10, Max: 20}}, 1},
+		{"disjoint lengths", []ValidationRule{{Type: RuleLength, Min: 1, Max: 2}, {Type: RuleLength, Min: 3, Max: 4}}, 1},
+		{"nested prefixes", []ValidationRule{{Type: RulePrefix, Pattern: "v"}, {Type: RulePrefix, Pattern: "v1."}}, 0},
+		{"incompatible prefixes", []ValidationRule{{Type: RulePrefix, Pattern: "v"}, {Type: RulePrefix, Pattern: "r"}}, 1},
+		{"incompatible suffixes", []ValidationRule{{Type: RuleSuffix, Pattern: ".json"}, {Type: RuleSuffix, Pattern: ".yml"}}, 1},
+		{"prefix longer than length", []ValidationRule{{Type: RuleLength, Min: 1, Max: 3}, {Type: RulePrefix, Pattern: "release-"}}, 1},
+		{"unrelated types", []ValidationRule{{Type: RuleRequired}, {Type: RuleRegex, Pattern: "x"}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := Conflicts(tt.rules); len(got) != tt.want {
+				t.Errorf("Conflicts() = %v, want %d conflicts", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRuleSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		spec     string
+		wantType Type
+		wantErr  error
+	}{
+		{name: "regex", spec: `regex:^v\d+$`, wantType: RuleRegex},
+		{name: "range", spec: " range:1-10 ", wantType: RuleRange},
+		{name: "required", spec: "required", wantType: RuleRequired},
+		{name: "semver", spec: "semver:>=1.2 <2", wantType: RuleSemver},
+		{name: "required_if", spec: "required_if:environment=production", wantType: RuleRequiredIf},
+		{name: "unknown", spec: "checksum", wantErr: ErrUnknownRuleType},
+		{name: "bad semver constraint", spec: "semver:>>1", wantErr: ErrInvalidSemverConstraint},
+		{name: "required_if without value", spec: "required_if:environment", wantErr: ErrRequiredIfFormat},
+		{name: "not_equal without input", spec: "not_equal:", wantErr: ErrNotEqualMissingField},
+		{name: "oneof-file without path", spec: "oneof-file:", wantErr: ErrOneOfFileMissingPath},
+		{name: "oneof-file absolute path", spec: "oneof-file:/etc/passwd", wantErr: ErrOneOfFileOutsideRepo},
+		{name: "oneof-file leaving the repo", spec: "oneof-file:deploy/../../secrets", wantErr: ErrOneOfFileOutsideRepo},
+		{name: "date layout without elements", spec: "date:YYYY-MM-DD", wantErr: ErrInvalidDateLayout},
+		{name: "semver operator apart from version", spec: "semver:>= 1.2, < 2", wantType: RuleSemver},
+		{name: "missing value", spec: "prefix:", wantErr: ErrPrefixRuleMissingValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRuleSpec(tt.spec)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Type != tt.wantType {
+				t.Errorf("type: got %v, want %v", got.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestValidateValue_Semver(t *testing.T) {
+	t.Parallel()
+
+	rule := func(constraint string) []ValidationRule {
+		return []ValidationRule{{Type: RuleSemver, Pattern: constraint}}
+	}
+
+	runValidateValueCases(t, []validateValueCase{
+		{name: "empty", value: "", rules: rule(""), wantErrors: 0},
+		{name: "plain", value: "1.2.3", rules: rule(""), wantErrors: 0},
+		{name: "v prefix and pre-release", value: "v2.0.0-rc.1+build.5", rules: rule(""), wantErrors: 0},
+		{name: "missing patch", value: "1.2", rules: rule(""), wantErrors: 1},
+		{name: "leading zero", value: "01.2.3", rules: rule(""), wantErrors: 1},
+		{name: "satisfies minimum", value: "1.2.0", rules: rule(">=1.2"), wantErrors: 0},
+		{name: "below minimum", value: "1.1.9", rules: rule(">=1.2"), wantErrors: 1},
+		{name: "pre-release below release", value: "1.2.0-beta", rules: rule(">=1.2"), wantErrors: 1},
+		{name: "within range", value: "1.9.0", rules: rule(">=1.2, <2"), wantErrors: 0},
+		{name: "outside range", value: "2.0.0", rules: rule(">=1.2 <2"), wantErrors: 1},
+		{name: "caret", value: "1.9.9", rules: rule("^1.2"), wantErrors: 0},
+		{name: "caret next major", value: "2.0.0", rules: rule("^1.2"), wantErrors: 1},
+		{name: "caret zero major", value: "0.3.0", rules: rule("^0.2.1"), wantErrors: 1},
+		{name: "tilde", value: "1.2.7", rules: rule("~1.2"), wantErrors: 0},
+		{name: "tilde next minor", value: "1.3.0", rules: rule("~1.2"), wantErrors: 1},
+		{name: "not equal", value: "1.2.3", rules: rule("!=1.2.3"), wantErrors: 1},
+		{name: "spaced operator below minimum", value: "1.1.0", rules: rule(">= 1.2"), wantErrors: 1},
+		{name: "spaced operator within range", value: "1.5.0", rules: rule(">= 1.2, < 2"), wantErrors: 0},
+	})
+}
+
+func TestValidateValue_Formats(t *testing.T) {
+	t.Parallel()
+
+	runValidateValueCases(t, []validateValueCase{
+		{name: "url", value: "https://example.com/path?q=1", rules: []ValidationRule{{Type: RuleURL}}, wantErrors: 0},
+		{name: "url without scheme", value: "example.com", rules: []ValidationRule{{Type: RuleURL}}, wantErrors: 1},
+		{name: "url without host", value: "file:///tmp/x", rules: []ValidationRule{{Type: RuleURL}}, wantErrors: 1},
+		{name: "json object", value: `{"replicas": 3}`, rules: []ValidationRule{{Type: RuleJSON}}, wantErrors: 0},
+		{name: "invalid json", value: `{replicas: 3}`, rules: []ValidationRule{{Type: RuleJSON}}, wantErrors: 1},
+		{
+			name: "date", value: "2026-02-28",
+			rules: []ValidationRule{{Type: RuleDate, Pattern: "2006-01-02"}}, wantErrors: 0,
+		},
+		{
+			name: "impossible date", value: "2026-02-30",
+			rules: []ValidationRule{{Type: RuleDate, Pattern: "2006-01-02"}}, wantErrors: 1,
+		},
+		{
+			name: "custom layout", value: "28/02/2026 14:30",
+			rules: []ValidationRule{{Type: RuleDate, Pattern: "02/01/2006 15:04"}}, wantErrors: 0,
+		},
+	})
+}
+
+func TestValidateValue_OneOfFile(t *testing.T) {
+	t.Parallel()
+
+	loaded := []ValidationRule{{Type: RuleOneOfFile, Pattern: "regions.txt", Values: []string{"us-east-1", "eu-west-1"}}}
+
+	runValidateValueCases(t, []validateValueCase{
+		{name: "allowed", value: "eu-west-1", rules: loaded, wantErrors: 0},
+		{name: "not allowed", value: "ap-south-1", rules: loaded, wantErrors: 1},
+		{name: "empty", value: "", rules: loaded, wantErrors: 0},
+		{
+			name: "not loaded", value: "eu-west-1",
+			rules: []ValidationRule{{Type: RuleOneOfFile, Pattern: "regions.txt"}}, wantErrors: 1,
+		},
+	})
+}
+
+func TestValidateValue_CrossField(t *testing.T) {
+	t.Parallel()
+
+	requiredIf := []ValidationRule{{Type: RuleRequiredIf, Field: "environment", Pattern: "production"}}
+	notEqual := []ValidationRule{{Type: RuleNotEqual, Field: "source"}}
+
+	runValidateValueCases(t, []validateValueCase{
+		{
+			name: "required when matching", value: "",
+			inputs: map[string]string{"environment": "production"}, rules: requiredIf, wantErrors: 1,
+		},
+		{
+			name: "optional otherwise", value: "",
+			inputs: map[string]string{"environment": "staging"}, rules: requiredIf, wantErrors: 0,
+		},
+		{name: "no inputs", value: "", rules: requiredIf, wantErrors: 0},
+		{
+			name: "equal to other", value: "main",
+			inputs: map[string]string{"source": "main"}, rules: notEqual, wantErrors: 1,
+		},
+		{
+			name: "differs from other", value: "release",
+			inputs: map[string]string{"source": "main"}, rules: notEqual, wantErrors: 0,
+		},
+	})
+}
+
+func TestValidateValue_NamesFailedRule(t *testing.T) {
+	t.Parallel()
+
+	rules := []ValidationRule{
+		{Type: RuleRange, Min: 1, Max: 10},
+		{Type: RuleRequiredIf, Field: "environment", Pattern: "production"},
+	}
+
+	got := ValidateValue("", rules, map[string]string{"environment": "production"})
+	want := []string{`value is required when environment is "production" (required_if:environment=production)`}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("ValidateValue() = %q, want %q", got, want)
+	}
+
+	if got := ValidateValue("11", rules, nil); !slices.Equal(got, []string{"must be between 1 and 10 (range:1-10)"}) {
+		t.Errorf("ValidateValue() = %q", got)
+	}
+}
+
+func TestLoadAllowedValues(t *testing.T) {
+	t.Parallel()
+
+	rules := []ValidationRule{
+		{Type: RuleRequired},
+		{Type: RuleOneOfFile, Pattern: "deploy/regions.txt"},
+		{Type: RuleOneOfFile, Pattern: "missing.txt"},
+	}
+
+	read := func(path string) ([]byte, error) {
+		if path != "deploy/regions.txt" {
+			return nil, os.ErrNotExist
+		}
+
+		return []byte("# supported regions\nus-east-1\n\n  eu-west-1  \n"), nil
+	}
+
+	loaded, err := LoadAllowedValues(rules, read)
+	if !errors.Is(err, os.ErrNotExist) || !strings.Contains(err.Error(), "missing.txt") {
+		t.Errorf("expected an error naming missing.txt, got %v", err)
+	}
+
+	if want := []string{"us-east-1", "eu-west-1"}; !slices.Equal(loaded[1].Values, want) {
+		t.Errorf("Values = %q, want %q", loaded[1].Values, want)
+	}
+
+	if loaded[2].Values != nil || rules[1].Values != nil {
+		t.Error("expected the unreadable rule and the original rules to stay without values")
+	}
+
+	if got := ValidateValue("us-east-1", loaded[2:], nil); len(got) != 1 || !strings.Contains(got[0], "missing.txt") {
+		t.Errorf("expected validation to report the unreadable file, got %q", got)
+	}
+}
+
+func TestValidationRule_String(t *testing.T) {
+	t.Parallel()
+
+	for _, spec := range []string{
+		"required", "regex:^v\\d+", "range:1-10", "length:0-5", "prefix:v", "semver", "semver:>=1.2 <2",
+		"url", "json", "date:2006-01-02", "oneof-file:regions.txt", "required_if:env=prod", "not_equal:source",
+	} {
+		r, err := ParseRuleSpec(spec)
+		if err != nil {
+			t.Fatalf("ParseRuleSpec(%q) failed: %v", spec, err)
+		}
+
+		if got := r.String(); got != spec {
+			t.Errorf("String() = %q, want %q", got, spec)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+package rule
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// semverPattern matches a semantic version with an optional leading "v",
+// capturing the major, minor and patch numbers and the pre-release.
+var semverPattern = regexp.MustCompile(
+	`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`,
+)
+
+// partialPattern matches the version in a constraint term, where the minor
+// and patch numbers may be left out.
+var partialPattern = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z.-]+))?$`)
+
+// semver is a parsed semantic version. Build metadata is dropped since it
+// does not affect precedence.
+type semver struct {
+	pre   string
+	major int
+	minor int
+	patch int
+}
+
+// parseSemver parses a full semantic version such as "1.2.3" or "v2.0.0-rc.1".
+func parseSemver(s string) (semver, bool) {
+	match := semverPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return semver{}, false
+	}
+
+	numbers, ok := atoiAll(match[1:4])
+	if !ok {
+		return semver{}, false
+	}
+
+	return semver{major: numbers[0], minor: numbers[1], patch: numbers[2], pre: match[4]}, true
+}
+
+// compare returns -1, 0 or 1 as v sorts before, equal to or after other,
+// following semver precedence.
+func (v semver) compare(other semver) int {
+	for _, diff := range []int{v.major - other.major, v.minor - other.minor, v.patch - other.patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+
+	switch {
+	case v.pre == other.pre:
+		return 0
+	case v.pre == "":
+		return 1
+	case other.pre == "":
+		return -1
+	}
+
+	return comparePrerelease(v.pre, other.pre)
+}
+
+// comparePrerelease compares dot-separated pre-release identifiers: numeric
+// identifiers compare numerically and sort before alphanumeric ones, and a
+// shorter list sorts first when all shared identifiers are equal.
+func comparePrerelease(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := range min(len(aParts), len(bParts)) {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				return sign(aNum - bNum)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(aParts[i], bParts[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return sign(len(aParts) - len(bParts))
+}
+
+// constraint is a set of version comparisons that must all hold.
+type constraint []comparison
+
+// comparison is a single term of a constraint, such as ">=1.2.0".
+type comparison struct {
+	op      string
+	version semver
+}
+
+// parseConstraint parses terms separated by spaces or commas, each an
+// operator (=, !=, >, >=, <, <=, ^ or ~) followed by a version whose minor
+// and patch numbers may be omitted. A bare version means =. "^1.2" allows
+// versions up to the next major release and "~1.2" up to the next minor.
+// An operator may be separated from its version by spaces, as in ">= 1.2".
+// An empty constraint allows every version.
+func parseConstraint(s string) (constraint, error) {
+	var c constraint
+
+	for _, term := range constraintTerms(s) {
+		start := strings.IndexAny(term, "0123456789v")
+		if start < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSemverConstraint, term)
+		}
+
+		op := term[:start]
+
+		version, parts, ok := parsePartial(term[start:])
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSemverConstraint, term)
+		}
+
+		switch op {
+		case "", "=":
+			c = append(c, comparison{op: "=", version: version})
+		case "!=", ">", ">=", "<", "<=":
+			c = append(c, comparison{op: op, version: version})
+		case "^":
+			c = append(c, comparison{op: ">=", version: version}, comparison{op: "<", version: nextCaret(version)})
+		case "~":
+			c = append(c, comparison{op: ">=", version: version}, comparison{op: "<", version: nextTilde(version, parts)})
+		default:
+			return nil, fmt.Errorf("%w: unknown operator in %q", ErrInvalidSemverConstraint, term)
+		}
+	}
+
+	return c, nil
+}
+
+// constraintTerms splits s into terms at spaces and commas, attaching an
+// operator that stands alone to the version after it.
+func constraintTerms(s string) []string {
+	var (
+		terms []string
+		op    string
+	)
+
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if strings.Trim(field, "=!<>^~") == "" {
+			op += field
+			continue
+		}
+
+		terms = append(terms, op+field)
+		op = ""
+	}
+
+	if op != "" {
+		terms = append(terms, op)
+	}
+
+	return terms
+}
+
+// allows returns true if v satisfies every comparison in c.
+func (c constraint) allows(v semver) bool {
+	for _, cmp := range c {
+		result := v.compare(cmp.version)
+
+		var ok bool
+
+		switch cmp.op {
+		case "=":
+			ok = result == 0
+		case "!=":
+			ok = result != 0
+		case ">":
+			ok = result > 0
+		case ">=":
+			ok = result >= 0
+		case "<":
+			ok = result < 0
+		case "<=":
+			ok = result <= 0
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parsePartial parses a constraint version, filling omitted numbers with zero
+// and reporting how many numbers were given.
+//
+//nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
+func parsePartial(s string) (semver, int, bool) {
+	match := partialPattern.FindStringSubmatch(s)
+	if match == nil {
+		return semver{}, 0, false
+	}
+
+	parts := 1
+	for _, group := range match[2:4] {
+		if group != "" {
+			parts++
+		}
+	}
+
+	numbers, ok := atoiAll(match[1:4])
+	if !ok {
+		return semver{}, 0, false
+	}
+
+	return semver{major: numbers[0], minor: numbers[1], patch: numbers[2], pre: match[4]}, parts, true
+}
+
+// nextCaret returns the first version a caret range excludes: the next major
+// release, or the next minor or patch release for 0.x versions.
+func nextCaret(v semver) semver {
+	switch {
+	case v.major > 0:
+		return semver{major: v.major + 1}
+	case v.minor > 0:
+		return semver{minor: v.minor + 1}
+	}
+
+	return semver{patch: v.patch + 1}
+}
+
+// nextTilde returns the first version a tilde range excludes: the next minor
+// release, or the next major release when only a major was given.
+func nextTilde(v semver, parts int) semver {
+	if parts == 1 {
+		return semver{major: v.major + 1}
+	}
+
+	return semver{major: v.major, minor: v.minor + 1}
+}
+
+// atoiAll converts each number, treating an empty string as zero.
+func atoiAll(numbers []string) ([]int, bool) {
+	values := make([]int, len(numbers))
+
+	for i, n := range numbers {
+		if n == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(n)
+		if err != nil {
+			return nil, false
+		}
+
+		values[i] = value
+	}
+
+	return values, true
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+
+	return 0
+}
@@ -5,11 +5,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
+	"time"
 
 	execpkg "github.com/kyleking/gh-lazydispatch/internal/exec"
+	"github.com/kyleking/gh-lazydispatch/internal/github"
 )
 
 const (
@@ -22,7 +26,16 @@ var ErrNoRunFound = errors.New("no run found for workflow")
 
 // RunConfig holds the configuration for running a workflow.
 type RunConfig struct {
-	Inputs   map[string]string
+	// Inputs holds the inputs to send. An input left out is unset and takes
+	// its default; an empty string is sent, overriding the default. See
+	// ExplicitInputs.
+	Inputs map[string]string
+	// InputTypes maps every input the workflow declares to its type. Nil
+	// sends every input as a string without checking it is declared.
+	InputTypes map[string]string
+	// Repo is the "owner/repo" to dispatch in, for runs without a local checkout.
+	// Empty dispatches in the repository of the working directory.
+	Repo     string
 	Workflow string
 	Branch   string
 	Watch    bool
@@ -69,13 +82,21 @@ func SetExecutor(cmdExec execpkg.CommandExecutor) {
 func BuildArgs(cfg RunConfig) []string {
 	args := []string{ghWorkflowArg, ghRunArg, cfg.Workflow}
 
+	if cfg.Repo != "" {
+		args = append(args, "--repo", cfg.Repo)
+	}
+
 	if cfg.Branch != "" {
 		args = append(args, "--ref", cfg.Branch)
 	}
 
-	for k, v := range cfg.Inputs {
-		if v != "" {
-			args = append(args, "-f", k+"="+v)
+	for _, name := range slices.Sorted(maps.Keys(cfg.Inputs)) {
+		value := cfg.Inputs[name]
+		if value != "" || sendsEmpty(cfg.InputTypes[name]) {
+			// gh workflow run sends every field as a string, and -F would
+			// read a value starting with @ from a file, so -f is always used.
+			// The REST dispatch types values through BuildPayload.
+			args = append(args, "-f", name+"="+value)
 		}
 	}
 
@@ -146,33 +167,57 @@ func DryRun(cfg RunConfig) string {
 	return FormatCommand(args)
 }
 
-// ExecuteAndGetRunID runs the workflow and returns the run ID for watching.
-// This polls the API shortly after dispatch to find the triggered run.
-func ExecuteAndGetRunID(cfg RunConfig, client GitHubClient) (int64, error) {
-	return ExecuteAndGetRunIDWithExecutor(cfg, client, executor)
+// DispatchResult identifies the run a dispatch started.
+type DispatchResult struct {
+	// URL is the run's page on GitHub.
+	URL   string
+	RunID int64
 }
 
-// ExecuteAndGetRunIDWithExecutor runs the workflow using the given executor and returns the triggered run ID.
-func ExecuteAndGetRunIDWithExecutor(cfg RunConfig, client GitHubClient, cmdExec CommandExecutor) (int64, error) {
-	args := BuildArgs(cfg)
+// Polling for a dispatched run when GitHub does not return its ID: runs
+// usually appear within a few seconds of the dispatch.
+const (
+	runLookupAttempts = 10
+	runLookupInterval = 2 * time.Second
+)
 
-	fmt.Println()
-	fmt.Println("Running command:")
-	fmt.Println("  " + FormatCommand(args))
-	fmt.Println()
+// Dispatch creates a workflow_dispatch event through the API and returns the
+// exact run it started, either from the run details GitHub returns or by
+// polling for the new run matching the dispatch's branch, actor and time.
+// cfg.Repo is ignored: the client determines the repository.
+func Dispatch(cfg RunConfig, client GitHubClient) (DispatchResult, error) {
+	return dispatch(cfg, client, time.Sleep)
+}
 
-	if err := cmdExec.Execute("gh", args...); err != nil {
-		return 0, fmt.Errorf("gh workflow run failed: %w", err)
+func dispatch(cfg RunConfig, client GitHubClient, sleep func(time.Duration)) (DispatchResult, error) {
+	payload, err := BuildPayload(cfg)
+	if err != nil {
+		return DispatchResult{}, err
 	}
 
-	run, err := client.GetLatestRun(cfg.Workflow)
+	resp, err := client.DispatchWorkflow(cfg.Workflow, payload)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get run ID: %w", err)
+		return DispatchResult{}, fmt.Errorf("dispatching %s: %w", cfg.Workflow, err)
 	}
 
-	if run == nil {
-		return 0, fmt.Errorf("%w: %s", ErrNoRunFound, cfg.Workflow)
+	if resp.RunID != 0 {
+		return DispatchResult{RunID: resp.RunID, URL: resp.HTMLURL}, nil
+	}
+
+	for attempt := range runLookupAttempts {
+		if attempt > 0 {
+			sleep(runLookupInterval)
+		}
+
+		run, err := client.FindDispatchedRun(cfg.Workflow, resp)
+		if err == nil {
+			return DispatchResult{RunID: run.ID, URL: run.HTMLURL}, nil
+		}
+
+		if !errors.Is(err, github.ErrNoWorkflowRuns) {
+			return DispatchResult{}, fmt.Errorf("finding dispatched run: %w", err)
+		}
 	}
 
-	return run.ID, nil
+	return DispatchResult{}, fmt.Errorf("%w: %s on %s", ErrNoRunFound, cfg.Workflow, resp.Ref)
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kyleking/gh-lazydispatch/internal/github"
 )
@@ -57,16 +58,33 @@ func TestBuildArgs(t *testing.T) {
 			},
 		},
 		{
-			name: "empty inputs skipped",
+			name: "explicitly empty strings sent, empty numbers left out",
+			cfg: RunConfig{
+				Workflow:   "deploy.yml",
+				Inputs:     map[string]string{"environment": "production", "note": "", "replicas": ""},
+				InputTypes: map[string]string{"environment": "choice", "note": "string", "replicas": "number"},
+			},
+			wantContains: []string{"environment=production", "-f note="},
+			wantExcludes: []string{"replicas="},
+		},
+		{
+			name: "explicit repository",
 			cfg: RunConfig{
 				Workflow: "deploy.yml",
-				Inputs: map[string]string{
-					"environment": "production",
-					"empty":       "",
-				},
+				Repo:     "owner/ops",
 			},
-			wantContains: []string{"environment=production"},
-			wantExcludes: []string{"empty="},
+			wantContains: []string{"--repo", "owner/ops"},
+			wantLen:      5,
+		},
+		{
+			name: "number inputs sent as raw fields",
+			cfg: RunConfig{
+				Workflow:   "deploy.yml",
+				Inputs:     map[string]string{"replicas": "3", "env": "prod"},
+				InputTypes: map[string]string{"replicas": "number", "env": "string"},
+			},
+			wantContains: []string{"-f", "replicas=3", "env=prod"},
+			wantExcludes: []string{"-F"},
 		},
 		{
 			name: "all options",
@@ -251,18 +269,47 @@ func (m *mockCommandExecutor) Execute(name string, args ...string) error {
 	return nil
 }
 
-// mockGitHubClient is a test double for GitHubClient.
+// mockGitHubClient is a test double for GitHubClient. Each FindDispatchedRun
+// call returns the next of runs, or ErrNoWorkflowRuns once they run out.
 type mockGitHubClient struct {
-	run *github.WorkflowRun
-	err error
+	dispatched  *github.DispatchResponse
+	dispatchErr error
+	findErr     error
+	requests    []dispatchCall
+	runs        []*github.WorkflowRun
+	findCalls   int
 }
 
-func (m *mockGitHubClient) GetLatestRun(_ string) (*github.WorkflowRun, error) {
-	if m.err != nil {
-		return nil, m.err
+// dispatchCall records the arguments of one DispatchWorkflow call.
+type dispatchCall struct {
+	payload  github.DispatchPayload
+	workflow string
+}
+
+func (m *mockGitHubClient) DispatchWorkflow(
+	workflow string, payload github.DispatchPayload,
+) (*github.DispatchResponse, error) {
+	m.requests = append(m.requests, dispatchCall{workflow: workflow, payload: payload})
+
+	if m.dispatchErr != nil {
+		return nil, m.dispatchErr
 	}
 
-	return m.run, nil
+	return m.dispatched, nil
+}
+
+func (m *mockGitHubClient) FindDispatchedRun(_ string, _ *github.DispatchResponse) (*github.WorkflowRun, error) {
+	m.findCalls++
+
+	if m.findErr != nil {
+		return nil, m.findErr
+	}
+
+	if m.findCalls > len(m.runs) || m.runs[m.findCalls-1] == nil {
+		return nil, github.ErrNoWorkflowRuns
+	}
+
+	return m.runs[m.findCalls-1], nil
 }
 
 // mockRepositoryDetector is a test double for RepositoryDetector.
@@ -394,83 +441,56 @@ func checkExecuteWithExecutorResult(
 	}
 }
 
-func TestExecuteAndGetRunIDWithExecutor(t *testing.T) {
+func TestDispatch(t *testing.T) {
 	t.Parallel()
 
+	run := &github.WorkflowRun{ID: 67890, HTMLURL: "https://github.com/owner/repo/actions/runs/67890"}
+
 	tests := []struct {
-		name           string
-		cfg            RunConfig
-		mockRun        *github.WorkflowRun
-		mockRunErr     error
-		execError      bool
-		errorOnCommand int
-		expectError    bool
-		expectRunID    int64
+		client    *mockGitHubClient
+		wantErr   error
+		name      string
+		want      DispatchResult
+		wantFinds int
+		wantSleep int
 	}{
 		{
-			name: "successful execution and retrieval",
-			cfg: RunConfig{
-				Workflow: "deploy.yml",
-				Branch:   "main",
-			},
-			mockRun: &github.WorkflowRun{
-				ID: 12345,
-			},
-			mockRunErr:     nil,
-			errorOnCommand: -1,
-			expectError:    false,
-			expectRunID:    12345,
+			name: "run details returned",
+			client: &mockGitHubClient{dispatched: &github.DispatchResponse{
+				RunID: 12345, HTMLURL: "https://github.com/owner/repo/actions/runs/12345",
+			}},
+			want: DispatchResult{RunID: 12345, URL: "https://github.com/owner/repo/actions/runs/12345"},
 		},
 		{
-			name: "command execution fails",
-			cfg: RunConfig{
-				Workflow: "test.yml",
+			name: "run found after polling",
+			client: &mockGitHubClient{
+				dispatched: &github.DispatchResponse{Ref: "main"},
+				runs:       []*github.WorkflowRun{nil, nil, run},
 			},
-			mockRun:        nil,
-			mockRunErr:     nil,
-			errorOnCommand: 0,
-			expectError:    true,
-			expectRunID:    0,
+			want:      DispatchResult{RunID: 67890, URL: run.HTMLURL},
+			wantFinds: 3,
+			wantSleep: 2,
 		},
 		{
-			name: "GetLatestRun API error",
-			cfg: RunConfig{
-				Workflow: "ci.yml",
-				Branch:   "feature",
-			},
-			mockRun:        nil,
-			mockRunErr:     errMockAPIError,
-			errorOnCommand: -1,
-			expectError:    true,
-			expectRunID:    0,
+			name:      "run never appears",
+			client:    &mockGitHubClient{dispatched: &github.DispatchResponse{Ref: "main"}},
+			wantErr:   ErrNoRunFound,
+			wantFinds: runLookupAttempts,
+			wantSleep: runLookupAttempts - 1,
 		},
 		{
-			name: "no run found",
-			cfg: RunConfig{
-				Workflow: "deploy.yml",
-			},
-			mockRun:        nil,
-			mockRunErr:     nil,
-			errorOnCommand: -1,
-			expectError:    true,
-			expectRunID:    0,
+			name:    "dispatch fails",
+			client:  &mockGitHubClient{dispatchErr: errMockAPIError},
+			wantErr: errMockAPIError,
 		},
 		{
-			name: "with inputs",
-			cfg: RunConfig{
-				Workflow: "test.yml",
-				Branch:   "develop",
-				Inputs: map[string]string{
-					"env": "test",
-				},
+			name: "run lookup fails",
+			client: &mockGitHubClient{
+				dispatched: &github.DispatchResponse{Ref: "main"},
+				findErr:    errMockAPIError,
 			},
-			mockRun: &github.WorkflowRun{
-				ID: 67890,
-			},
-			mockRunErr:     nil,
-			errorOnCommand: -1,
-			expectError:    false,
-			expectRunID:    67890,
+			wantErr:   errMockAPIError,
+			wantFinds: 1,
 		},
 	}
 
@@ -478,31 +498,28 @@ func TestExecuteAndGetRunIDWithExecutor(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			mockExec := &mockCommandExecutor{
-				errorOnCommand: tt.errorOnCommand,
-				errToReturn:    errMockCommandFailed,
-			}
-			mockClient := &mockGitHubClient{
-				run: tt.mockRun,
-				err: tt.mockRunErr,
-			}
+			cfg := RunConfig{Workflow: "deploy.yml", Branch: "main", Inputs: map[string]string{"env": "prod"}}
+			sleeps := 0
 
-			runID, err := ExecuteAndGetRunIDWithExecutor(tt.cfg, mockClient, mockExec)
-			checkExecuteAndGetRunIDResult(t, runID, err, tt.expectError, tt.expectRunID)
-		})
-	}
-}
+			got, err := dispatch(cfg, tt.client, func(time.Duration) { sleeps++ })
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("dispatch() error = %v, want %v", err, tt.wantErr)
+			}
 
-// checkExecuteAndGetRunIDResult asserts the result of ExecuteAndGetRunIDWithExecutor against expectations.
-func checkExecuteAndGetRunIDResult(t *testing.T, runID int64, err error, expectError bool, expectRunID int64) {
-	t.Helper()
+			if got != tt.want {
+				t.Errorf("dispatch() = %+v, want %+v", got, tt.want)
+			}
 
-	if (err != nil) != expectError {
-		t.Errorf("ExecuteAndGetRunIDWithExecutor() error = %v, expectError %v", err, expectError)
-	}
+			if tt.client.findCalls != tt.wantFinds || sleeps != tt.wantSleep {
+				t.Errorf("got %d lookups and %d sleeps, want %d and %d",
+					tt.client.findCalls, sleeps, tt.wantFinds, tt.wantSleep)
+			}
 
-	if runID != expectRunID {
-		t.Errorf("ExecuteAndGetRunIDWithExecutor() runID = %d, want %d", runID, expectRunID)
+			req := tt.client.requests[0]
+			if req.workflow != "deploy.yml" || req.payload.Ref != "main" || req.payload.Inputs["env"] != "prod" {
+				t.Errorf("dispatch request = %+v", req)
+			}
+		})
 	}
 }
 
@@ -4,5 +4,6 @@ import "github.com/kyleking/gh-lazydispatch/internal/github"
 
 // GitHubClient defines the interface for GitHub API operations needed by the runner.
 type GitHubClient interface {
-	GetLatestRun(workflowName string) (*github.WorkflowRun, error)
+	DispatchWorkflow(workflow string, payload github.DispatchPayload) (*github.DispatchResponse, error)
+	FindDispatchedRun(workflow string, dispatched *github.DispatchResponse) (*github.WorkflowRun, error)
 }
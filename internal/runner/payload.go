@@ -0,0 +1,134 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kyleking/gh-lazydispatch/internal/github"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// Errors returned while building a dispatch payload.
+var (
+	ErrUndeclaredInput   = errors.New("input is not declared by the workflow")
+	ErrInvalidInputValue = errors.New("invalid input value")
+)
+
+// IsSet reports whether value sets input rather than leaving it to its
+// default: any non-empty value, or an empty one that clears a non-empty
+// default. An empty value for an input whose default is also empty means
+// "use the default": GitHub fills in the same empty string either way, so
+// there is no clear to tell apart from it.
+func IsSet(input workflow.Input, value string) bool {
+	return value != "" || input.Default != ""
+}
+
+// ExplicitInputs returns the values a dispatch of wf sends, for
+// RunConfig.Inputs: those that IsSet, plus non-empty values for inputs wf
+// does not declare, which BuildPayload rejects. Empty values with an empty
+// default are dropped here, not in BuildPayload.
+func ExplicitInputs(wf workflow.File, values map[string]string) map[string]string {
+	inputs := wf.GetInputs()
+	explicit := make(map[string]string, len(values))
+
+	for name, value := range values {
+		input, declared := inputs[name]
+		if (declared && IsSet(input, value)) || (!declared && value != "") {
+			explicit[name] = value
+		}
+	}
+
+	return explicit
+}
+
+// BuildPayload encodes cfg as the body of a workflow_dispatch request. Every
+// input in cfg.Inputs is sent, so an empty string overrides the input's
+// default; an empty boolean or number has no such meaning and is left out.
+// Deciding which inputs to include is the caller's job, normally through
+// ExplicitInputs.
+// Booleans are sent as JSON booleans and numbers as JSON numbers. When
+// cfg.InputTypes is set, inputs it does not list are ErrUndeclaredInput;
+// when it is nil, every input is sent as a string.
+func BuildPayload(cfg RunConfig) (github.DispatchPayload, error) {
+	payload := github.DispatchPayload{Ref: cfg.Branch}
+
+	var errs []error
+
+	for _, name := range slices.Sorted(maps.Keys(cfg.Inputs)) {
+		inputType, declared := cfg.InputTypes[name]
+		if cfg.InputTypes != nil && !declared {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUndeclaredInput, name))
+			continue
+		}
+
+		value, ok, err := encodeInput(inputType, cfg.Inputs[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+
+		if !ok {
+			continue
+		}
+
+		if payload.Inputs == nil {
+			payload.Inputs = make(map[string]any, len(cfg.Inputs))
+		}
+
+		payload.Inputs[name] = value
+	}
+
+	if len(errs) > 0 {
+		return github.DispatchPayload{}, errors.Join(errs...)
+	}
+
+	return payload, nil
+}
+
+// encodeInput converts value to the JSON value sent for an input of
+// inputType, reporting false for an empty boolean or number.
+//
+//nolint:gocritic // unnamedResult wants named returns, but nonamedreturns forbids them
+func encodeInput(inputType, value string) (any, bool, error) {
+	if value == "" && !sendsEmpty(inputType) {
+		return nil, false, nil
+	}
+
+	switch inputType {
+	case workflow.InputTypeBoolean:
+		if value != "true" && value != "false" {
+			return nil, false, fmt.Errorf("%w: %q is not true or false", ErrInvalidInputValue, value)
+		}
+
+		return value == "true", true, nil
+	case workflow.InputTypeNumber:
+		// json.Number keeps the value as written, so "1.50" is not sent as 1.5.
+		if !workflow.IsNumber(value) {
+			return nil, false, fmt.Errorf("%w: %q is not a number", ErrInvalidInputValue, value)
+		}
+
+		return json.Number(value), true, nil
+	}
+
+	return value, true, nil
+}
+
+// sendsEmpty reports whether an empty value of inputType is sent rather than
+// left out: true for everything but booleans and numbers.
+func sendsEmpty(inputType string) bool {
+	return inputType != workflow.InputTypeBoolean && inputType != workflow.InputTypeNumber
+}
+
+// FormatPayload returns payload as indented JSON.
+func FormatPayload(payload github.DispatchPayload) string {
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		// Only strings, booleans and validated numbers are ever encoded.
+		return fmt.Sprintf("%+v", payload)
+	}
+
+	return string(data)
+}
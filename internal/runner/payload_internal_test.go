@@ -0,0 +1,106 @@
+package runner
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+func TestBuildPayload(t *testing.T) {
+	t.Parallel()
+
+	types := map[string]string{
+		"env": "choice", "note": "string", "dry_run": "boolean", "replicas": "number", "ratio": "number",
+	}
+
+	tests := []struct {
+		name    string
+		cfg     RunConfig
+		want    string
+		wantErr error
+	}{
+		{
+			name: "typed values",
+			cfg: RunConfig{
+				Branch:     "main",
+				Inputs:     map[string]string{"env": "prod", "dry_run": "true", "replicas": "3", "ratio": "1.50"},
+				InputTypes: types,
+			},
+			want: `{"inputs":{"dry_run":true,"env":"prod","ratio":1.50,"replicas":3},"ref":"main"}`,
+		},
+		{
+			name: "explicit empty string sent, empty boolean and number left out",
+			cfg: RunConfig{
+				Inputs:     map[string]string{"note": "", "dry_run": "", "replicas": ""},
+				InputTypes: types,
+			},
+			want: `{"inputs":{"note":""},"ref":""}`,
+		},
+		{
+			name: "untyped inputs sent as strings",
+			cfg:  RunConfig{Inputs: map[string]string{"replicas": "3", "extra": ""}},
+			want: `{"inputs":{"extra":"","replicas":"3"},"ref":""}`,
+		},
+		{
+			name:    "undeclared input",
+			cfg:     RunConfig{Inputs: map[string]string{"env": "prod", "stale": "x"}, InputTypes: types},
+			wantErr: ErrUndeclaredInput,
+		},
+		{
+			name:    "boolean that is not true or false",
+			cfg:     RunConfig{Inputs: map[string]string{"dry_run": "yes"}, InputTypes: types},
+			wantErr: ErrInvalidInputValue,
+		},
+		{
+			name:    "number that is not finite",
+			cfg:     RunConfig{Inputs: map[string]string{"replicas": "Inf"}, InputTypes: types},
+			wantErr: ErrInvalidInputValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			payload, err := BuildPayload(tt.cfg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BuildPayload() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			got, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("marshal failed: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("BuildPayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplicitInputs(t *testing.T) {
+	t.Parallel()
+
+	wf := workflow.File{On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+		"env":   {Type: "string", Default: "staging"},
+		"note":  {Type: "string"},
+		"debug": {Type: "boolean", Default: "false"},
+	}}}}
+
+	got := ExplicitInputs(wf, map[string]string{
+		"env": "", "note": "", "debug": "false", "stale": "x", "gone": "",
+	})
+	want := map[string]string{"env": "", "debug": "false", "stale": "x"}
+
+	if !maps.Equal(got, want) {
+		t.Errorf("ExplicitInputs() = %v, want %v", got, want)
+	}
+}
@@ -7,27 +7,29 @@ import (
 
 // MockGitHubClient implements both chain.GitHubClient and watcher.GitHubClient interfaces.
 type MockGitHubClient struct {
-	Err              error
-	Runs             map[int64]*github.WorkflowRun
-	Jobs             map[int64][]github.Job
-	LatestByWorkflow map[string]int64
-	owner            string
-	repo             string
-	LatestID         int64
+	Err  error
+	Runs map[int64]*github.WorkflowRun
+	Jobs map[int64][]github.Job
+	// DispatchedByWorkflow maps workflows to the run ID their dispatch starts.
+	DispatchedByWorkflow map[string]int64
+	owner                string
+	repo                 string
+	// DispatchedID is the run ID started by dispatching any other workflow.
+	DispatchedID int64
 }
 
-// defaultMockLatestID is an arbitrary starting run ID for mock-generated runs.
-const defaultMockLatestID = 1000
+// defaultMockDispatchedID is an arbitrary run ID for mock-dispatched runs.
+const defaultMockDispatchedID = 1000
 
 // NewMockGitHubClient creates a MockGitHubClient with sensible defaults.
 func NewMockGitHubClient() *MockGitHubClient {
 	return &MockGitHubClient{
-		Runs:             make(map[int64]*github.WorkflowRun),
-		Jobs:             make(map[int64][]github.Job),
-		LatestByWorkflow: make(map[string]int64),
-		LatestID:         defaultMockLatestID,
-		owner:            "owner",
-		repo:             "repo",
+		Runs:                 make(map[int64]*github.WorkflowRun),
+		Jobs:                 make(map[int64][]github.Job),
+		DispatchedByWorkflow: make(map[string]int64),
+		DispatchedID:         defaultMockDispatchedID,
+		owner:                "owner",
+		repo:                 "repo",
 	}
 }
 
@@ -79,17 +81,40 @@ func (m *MockGitHubClient) GetWorkflowRunJobs(runID int64) ([]github.Job, error)
 	return m.Jobs[runID], nil
 }
 
-// GetLatestRun returns the mocked latest run for workflow.
-func (m *MockGitHubClient) GetLatestRun(workflow string) (*github.WorkflowRun, error) {
+// DispatchWorkflow returns the mocked run started by dispatching workflow,
+// with its URL if the run is configured.
+func (m *MockGitHubClient) DispatchWorkflow(
+	workflow string, payload github.DispatchPayload,
+) (*github.DispatchResponse, error) {
 	if m.Err != nil {
 		return nil, m.Err
 	}
-	// Check if there's a specific run ID for this workflow
-	if runID, ok := m.LatestByWorkflow[workflow]; ok {
-		return &github.WorkflowRun{ID: runID, Status: github.StatusQueued}, nil
+
+	run := m.dispatchedRun(workflow)
+
+	return &github.DispatchResponse{RunID: run.ID, HTMLURL: run.HTMLURL, Ref: payload.Ref}, nil
+}
+
+// FindDispatchedRun returns the mocked run started by dispatching workflow.
+func (m *MockGitHubClient) FindDispatchedRun(workflow string, _ *github.DispatchResponse) (*github.WorkflowRun, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	return m.dispatchedRun(workflow), nil
+}
+
+func (m *MockGitHubClient) dispatchedRun(workflow string) *github.WorkflowRun {
+	runID, ok := m.DispatchedByWorkflow[workflow]
+	if !ok {
+		runID = m.DispatchedID
 	}
-	// Fall back to default LatestID
-	return &github.WorkflowRun{ID: m.LatestID, Status: github.StatusQueued}, nil
+
+	if run, ok := m.Runs[runID]; ok {
+		return run
+	}
+
+	return &github.WorkflowRun{ID: runID, Status: github.StatusQueued}
 }
 
 // Owner returns the mocked repository owner.
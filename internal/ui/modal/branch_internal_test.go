@@ -257,3 +257,23 @@ func TestBranchModalStylesHaveNoBackground(t *testing.T) {
 		t.Error("View seems too short, possible rendering issue")
 	}
 }
+
+func TestSimpleBranchModal_Loading(t *testing.T) {
+	t.Parallel()
+
+	modal := NewSimpleBranchModal("Select Branch", nil, "feature", "").Loading()
+
+	if view := modal.View(); !strings.Contains(view, "Loading branches") {
+		t.Errorf("expected a loading message, got:\n%s", view)
+	}
+
+	if _, cmd := modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil || modal.IsDone() {
+		t.Error("expected enter to do nothing while loading")
+	}
+
+	modal.SetBranches([]string{"develop", "feature", "main"}, "main")
+
+	if strings.Contains(modal.View(), "Loading branches") || modal.filteredBranches[modal.selected] != "feature" {
+		t.Errorf("expected the branches listed with the current branch selected, got %v", modal.filteredBranches)
+	}
+}
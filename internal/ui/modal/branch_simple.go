@@ -26,6 +26,7 @@ type SimpleBranchModal struct {
 	scrollOffset     int
 	done             bool
 	filtering        bool
+	loading          bool
 }
 
 type simpleBranchKeyMap struct {
@@ -89,6 +90,36 @@ func NewSimpleBranchModal(title string, branches []string, current, defaultBranc
 	}
 }
 
+// Loading marks the modal as waiting for its branches, which SetBranches
+// supplies. Until then it lists nothing and Enter does nothing.
+func (m *SimpleBranchModal) Loading() *SimpleBranchModal {
+	m.loading = true
+	return m
+}
+
+// SetBranches replaces the listed branches and the default branch, ending the
+// loading state, and selects the current branch if it is among them.
+func (m *SimpleBranchModal) SetBranches(branches []string, defaultBranch string) {
+	m.loading = false
+	m.allBranches = branches
+	m.defaultBranch = defaultBranch
+	m.pinnedBranches = _pinBranches(branches, m.currentBranch, defaultBranch)
+	m.applyFilter()
+
+	if m.filterInput.Value() != "" {
+		return
+	}
+
+	for i, branch := range m.pinnedBranches {
+		if branch == m.currentBranch {
+			m.selected = i
+			m.adjustScroll()
+
+			break
+		}
+	}
+}
+
 // simpleBranchModalChrome is the vertical space reserved for the title, filter input, and help text.
 const simpleBranchModalChrome = 6
 
@@ -161,6 +192,10 @@ func (m *SimpleBranchModal) updateNavigating(msg tea.KeyPressMsg) (Context, tea.
 			m.adjustScroll()
 		}
 	case key.Matches(msg, m.keys.Enter):
+		if m.loading {
+			return m, nil
+		}
+
 		if m.selected < len(m.filteredBranches) {
 			m.result = m.filteredBranches[m.selected]
 		}
@@ -264,6 +299,11 @@ func (m *SimpleBranchModal) View() string {
 // renderBranchList writes the visible slice of filtered branches (with cursor,
 // selection style, and current/default indicators) plus a scroll indicator.
 func (m *SimpleBranchModal) renderBranchList(s *strings.Builder, endIdx, visibleLines int) {
+	if m.loading {
+		s.WriteString(ui.SubtitleStyle.Render("Loading branches..."))
+		return
+	}
+
 	if len(m.filteredBranches) == 0 {
 		s.WriteString(ui.SubtitleStyle.Render("No branches found"))
 		return
@@ -58,6 +58,7 @@ func (*HelpModal) View() string {
   /                  Start filtering inputs
   c                  Command - copy to clipboard
   r                  Reset all inputs to defaults
+  S                  Save inputs as a shared preset
 
 ` + ui.SubtitleStyle.Render("Input Editing") + `
   Ctrl+R             Restore default value
@@ -32,6 +32,7 @@ type InputModal struct {
 	keys            inputKeyMap
 	options         []string
 	validationRules []rule.ValidationRule
+	inputs          map[string]string
 	input           textinput.Model
 	done            bool
 	hasError        bool
@@ -55,6 +56,20 @@ func defaultInputKeyMap() inputKeyMap {
 func NewInputModal(
 	title, description, defaultVal, inputType, current string, options []string, rules []rule.ValidationRule,
 ) *InputModal {
+	return &InputModal{
+		title:           title,
+		description:     description,
+		defaultVal:      defaultVal,
+		inputType:       inputType,
+		options:         options,
+		validationRules: rules,
+		input:           newModalTextInput(current),
+		keys:            defaultInputKeyMap(),
+	}
+}
+
+// newModalTextInput returns a focused single-line text input styled for use inside a modal.
+func newModalTextInput(current string) textinput.Model {
 	ti := textinput.New()
 	ti.SetValue(current)
 	ti.Focus()
@@ -73,16 +88,14 @@ func NewInputModal(
 	s.Blurred.Suggestion = s.Blurred.Suggestion.UnsetBackground()
 	ti.SetStyles(s)
 
-	return &InputModal{
-		title:           title,
-		description:     description,
-		defaultVal:      defaultVal,
-		inputType:       inputType,
-		options:         options,
-		validationRules: rules,
-		input:           ti,
-		keys:            defaultInputKeyMap(),
-	}
+	return ti
+}
+
+// WithInputs sets the current value of every input, for validation rules
+// that compare against another input.
+func (m *InputModal) WithInputs(inputs map[string]string) *InputModal {
+	m.inputs = inputs
+	return m
 }
 
 func (m *InputModal) validate() string {
@@ -104,7 +117,7 @@ func (m *InputModal) validate() string {
 	}
 
 	if len(m.validationRules) > 0 {
-		errors := rule.ValidateValue(value, m.validationRules)
+		errors := rule.ValidateValue(value, m.validationRules, m.inputs)
 		if len(errors) > 0 {
 			return strings.Join(errors, "; ")
 		}
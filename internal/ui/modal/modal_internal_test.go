@@ -1,11 +1,16 @@
 package modal
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
 
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
 	"github.com/kyleking/gh-lazydispatch/internal/runner"
+	"github.com/kyleking/gh-lazydispatch/internal/validation"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
 )
 
 func TestStack_PushPop(t *testing.T) {
@@ -123,6 +128,22 @@ func TestSelectModal_Escape(t *testing.T) {
 	}
 }
 
+func TestSelectModal_Annotations(t *testing.T) {
+	t.Parallel()
+
+	modal := NewSelectModal("target", []string{"staging", "production"}, "", "").
+		WithAnnotations(map[string]string{"production": "reviewers: alice"})
+
+	view := modal.View()
+	if !strings.Contains(view, "reviewers: alice") {
+		t.Errorf("expected annotation in view, got:\n%s", view)
+	}
+
+	if strings.Count(view, "reviewers") != 1 {
+		t.Errorf("expected only production to be annotated, got:\n%s", view)
+	}
+}
+
 func TestInputModal_Enter(t *testing.T) {
 	t.Parallel()
 
@@ -157,6 +178,96 @@ func TestInputModal_Escape(t *testing.T) {
 	}
 }
 
+func TestInputModal_CrossFieldRule(t *testing.T) {
+	t.Parallel()
+
+	rules := []rule.ValidationRule{{Type: rule.RuleNotEqual, Field: "source"}}
+	modal := NewInputModal("target", "", "", "string", "main", nil, rules).
+		WithInputs(map[string]string{"source": "main"})
+
+	modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if modal.IsDone() {
+		t.Fatal("expected the first enter to show the validation error")
+	}
+
+	if view := modal.View(); !strings.Contains(view, "must differ from source (not_equal:source)") {
+		t.Errorf("expected the failed rule in the view, got:\n%s", view)
+	}
+}
+
+func TestNumberInputModal_RejectsNonNumeric(t *testing.T) {
+	t.Parallel()
+
+	modal := NewNumberInputModal("replicas", "", "1", "1", nil)
+
+	for _, r := range "a2.5x" {
+		modal.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+
+	if got := modal.input.Value(); got != "12.5" {
+		t.Errorf("expected only numeric keystrokes to be accepted, got %q", got)
+	}
+
+	modal.Update(tea.KeyPressMsg{Code: '.', Text: "."})
+
+	if got := modal.input.Value(); got != "12.5" {
+		t.Errorf("expected second decimal point to be rejected, got %q", got)
+	}
+}
+
+func TestNumberInputModal_StepClampsToRange(t *testing.T) {
+	t.Parallel()
+
+	rules := []rule.ValidationRule{{Type: rule.RuleRange, Min: 1, Max: 5}}
+	modal := NewNumberInputModal("replicas", "", "3", "", rules)
+
+	up := tea.KeyPressMsg{Code: tea.KeyUp}
+	modal.Update(up)
+
+	if got := modal.input.Value(); got != "4" {
+		t.Errorf("expected empty value to step from default to 4, got %q", got)
+	}
+
+	modal.Update(tea.KeyPressMsg{Code: tea.KeyPgUp})
+
+	if got := modal.input.Value(); got != "5" {
+		t.Errorf("expected large step to clamp at max 5, got %q", got)
+	}
+
+	for range 10 {
+		modal.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+
+	if got := modal.input.Value(); got != "1" {
+		t.Errorf("expected decrement to clamp at min 1, got %q", got)
+	}
+
+	if !strings.Contains(modal.View(), "Range: 1 to 5") {
+		t.Error("expected range hint in view")
+	}
+}
+
+func TestNumberInputModal_Enter(t *testing.T) {
+	t.Parallel()
+
+	rules := []rule.ValidationRule{{Type: rule.RuleRange, Min: 1, Max: 5}}
+	modal := NewNumberInputModal("replicas", "", "", "9", rules)
+
+	enter := tea.KeyPressMsg{Code: tea.KeyEnter}
+	modal.Update(enter)
+
+	if modal.IsDone() {
+		t.Fatal("expected out-of-range value to show a validation error first")
+	}
+
+	modal.Update(enter)
+
+	if !modal.IsDone() || modal.Result() != "9" {
+		t.Errorf("expected apply-anyway to return 9, got done=%v result=%v", modal.IsDone(), modal.Result())
+	}
+}
+
 func TestConfirmModal_Navigation(t *testing.T) {
 	t.Parallel()
 
@@ -485,3 +596,133 @@ func TestValidationErrorModal_Cancel(t *testing.T) {
 		t.Error("expected override=false after escape")
 	}
 }
+
+func TestPickerModal_FiltersAndSelects(t *testing.T) {
+	t.Parallel()
+
+	modal := NewPickerModal("tag", "git tags", []string{"v1.2.0", "v1.1.0", "v1.0.0", "nightly"}, "v1.1.0", "v1.0.0")
+
+	if got := modal.matches[modal.selected]; got != "v1.1.0" {
+		t.Fatalf("expected the current value selected, got %q", got)
+	}
+
+	for _, r := range "ngt" {
+		modal.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+
+	if len(modal.matches) != 1 || modal.matches[0] != "nightly" {
+		t.Fatalf("expected fuzzy filter to match nightly, got %v", modal.matches)
+	}
+
+	modal.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+
+	if modal.IsDone() || len(modal.matches) != 4 {
+		t.Fatalf("expected esc to clear the filter first, got done=%v matches=%v", modal.IsDone(), modal.matches)
+	}
+
+	modal.Update(tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl})
+	_, cmd := modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if !modal.IsDone() || cmd == nil {
+		t.Fatal("expected enter to select")
+	}
+
+	if msg, ok := cmd().(SelectResultMsg); !ok || msg.Value != "v1.0.0" {
+		t.Errorf("expected the default to be selected, got %#v", cmd())
+	}
+}
+
+func TestPickerModal_EnterWithoutMatches(t *testing.T) {
+	t.Parallel()
+
+	modal := NewPickerModal("tag", "git tags", []string{"v1.0.0"}, "", "")
+
+	modal.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+
+	if _, cmd := modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil || modal.IsDone() {
+		t.Error("expected enter to do nothing when no option matches")
+	}
+
+	if view := modal.View(); !strings.Contains(view, "No matches") {
+		t.Errorf("expected an empty-state message, got:\n%s", view)
+	}
+}
+
+func TestPickerModal_Loading(t *testing.T) {
+	t.Parallel()
+
+	modal := NewPickerModal("tag", "git tags", nil, "v1.1.0", "").Loading()
+
+	if view := modal.View(); !strings.Contains(view, "Loading options") {
+		t.Errorf("expected a loading message, got:\n%s", view)
+	}
+
+	if _, cmd := modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Error("expected enter to do nothing while loading")
+	}
+
+	modal.SetOptions([]string{"v1.2.0", "v1.1.0"})
+
+	if strings.Contains(modal.View(), "Loading options") || modal.matches[modal.selected] != "v1.1.0" {
+		t.Errorf("expected the options listed with the current value selected, got %v", modal.matches)
+	}
+}
+
+func TestRemapModal_ValueErrorsOfferCoercions(t *testing.T) {
+	t.Parallel()
+
+	inputs := map[string]workflow.Input{
+		"debug":  {Type: "boolean"},
+		"env":    {Type: "choice", Options: []string{"production", "staging"}},
+		"region": {Type: "string", Required: true},
+	}
+	errs := []validation.ConfigValidationError{
+		{HistoricalName: "debug", HistoricalValue: "yes", Status: validation.StatusTypeChanged, Suggestion: "true"},
+		{HistoricalName: "env", HistoricalValue: "prod", Status: validation.StatusOptionsChanged, Suggestion: "production"},
+		{HistoricalName: "region", Status: validation.StatusRequiredMissing},
+	}
+
+	modal := NewRemapModal(errs, inputs)
+
+	if got := modal.options[0].label; got != "Use: true" {
+		t.Fatalf("expected the coerced value first, got %q", got)
+	}
+
+	modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	labels := make([]string, 0, len(modal.options))
+	for _, opt := range modal.options {
+		labels = append(labels, opt.label)
+	}
+
+	want := []string{"Use: production", "Use: staging", "Drop this input", "Keep original (ignore error)"}
+	if !slices.Equal(labels, want) {
+		t.Fatalf("expected choice options, got %v", labels)
+	}
+
+	modal.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if len(modal.options) != 1 || modal.options[0].label != "Leave empty" {
+		t.Fatalf("expected only leave empty for a required input without a suggestion, got %v", modal.options)
+	}
+
+	_, cmd := modal.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected a result after the last error")
+	}
+
+	msg, ok := cmd().(RemapResultMsg)
+	if !ok {
+		t.Fatalf("expected RemapResultMsg, got %#v", cmd())
+	}
+
+	wantDecisions := []RemapDecision{
+		{OriginalName: "debug", NewValue: "true", Action: RemapActionSet},
+		{OriginalName: "env", NewValue: "staging", Action: RemapActionSet},
+		{OriginalName: "region", Action: RemapActionKeep},
+	}
+	if !slices.Equal(msg.Decisions, wantDecisions) {
+		t.Errorf("decisions = %+v, want %+v", msg.Decisions, wantDecisions)
+	}
+}
@@ -0,0 +1,267 @@
+package modal
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+	"github.com/kyleking/gh-lazydispatch/internal/ui"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+// Step sizes for the number modal's increment and decrement keys.
+const (
+	numberSmallStep = 1
+	numberLargeStep = 10
+)
+
+// numberPartialPattern matches any prefix of a decimal number, so edits are
+// accepted while the user is still typing (e.g. "-" or "3.").
+var numberPartialPattern = regexp.MustCompile(`^-?\d*\.?\d*$`)
+
+// NumberInputModal presents a numeric input field that rejects non-numeric
+// keystrokes and steps the value up or down within any range rules.
+type NumberInputModal struct {
+	title           string
+	description     string
+	defaultVal      string
+	result          string
+	validationErr   string
+	keys            numberKeyMap
+	validationRules []rule.ValidationRule
+	inputs          map[string]string
+	input           textinput.Model
+	minVal          int
+	maxVal          int
+	bounded         bool
+	done            bool
+	hasError        bool
+}
+
+type numberKeyMap struct {
+	Decrement      key.Binding
+	DecrementLarge key.Binding
+	Enter          key.Binding
+	Escape         key.Binding
+	Increment      key.Binding
+	IncrementLarge key.Binding
+	RestoreDefault key.Binding
+}
+
+func defaultNumberKeyMap() numberKeyMap {
+	return numberKeyMap{
+		Decrement:      key.NewBinding(key.WithKeys("down")),
+		DecrementLarge: key.NewBinding(key.WithKeys("pgdown")),
+		Enter:          key.NewBinding(key.WithKeys("enter")),
+		Escape:         key.NewBinding(key.WithKeys("esc")),
+		Increment:      key.NewBinding(key.WithKeys("up")),
+		IncrementLarge: key.NewBinding(key.WithKeys("pgup")),
+		RestoreDefault: key.NewBinding(key.WithKeys("ctrl+r", "alt+d")),
+	}
+}
+
+// NewNumberInputModal creates a new numeric input modal.
+// Range rules in rules bound the increment and decrement keys.
+func NewNumberInputModal(title, description, defaultVal, current string, rules []rule.ValidationRule) *NumberInputModal {
+	m := &NumberInputModal{
+		title:           title,
+		description:     description,
+		defaultVal:      defaultVal,
+		validationRules: rules,
+		input:           newModalTextInput(current),
+		keys:            defaultNumberKeyMap(),
+		minVal:          math.MinInt,
+		maxVal:          math.MaxInt,
+	}
+
+	for _, r := range rules {
+		if r.Type != rule.RuleRange {
+			continue
+		}
+
+		m.bounded = true
+		m.minVal = max(m.minVal, r.Min)
+		m.maxVal = min(m.maxVal, r.Max)
+	}
+
+	return m
+}
+
+// WithInputs sets the current value of every input, for validation rules
+// that compare against another input.
+func (m *NumberInputModal) WithInputs(inputs map[string]string) *NumberInputModal {
+	m.inputs = inputs
+	return m
+}
+
+func (m *NumberInputModal) validate() string {
+	value := m.input.Value()
+
+	if value != "" && !workflow.IsNumber(value) {
+		return "\"" + value + "\" is not a number"
+	}
+
+	if len(m.validationRules) > 0 {
+		errors := rule.ValidateValue(value, m.validationRules, m.inputs)
+		if len(errors) > 0 {
+			return strings.Join(errors, "; ")
+		}
+	}
+
+	return ""
+}
+
+// step adds delta to the current value, clamped to any range rules.
+// An empty or unparsable value steps from the default, or from zero.
+func (m *NumberInputModal) step(delta int) {
+	base := m.input.Value()
+	if !workflow.IsNumber(base) {
+		base = m.defaultVal
+	}
+
+	num, err := strconv.ParseFloat(base, 64)
+	if err != nil || !workflow.IsNumber(base) {
+		num = 0
+	}
+
+	num += float64(delta)
+
+	if m.bounded {
+		num = math.Max(float64(m.minVal), math.Min(float64(m.maxVal), num))
+	}
+
+	m.setValue(strconv.FormatFloat(num, 'f', -1, 64))
+}
+
+func (m *NumberInputModal) setValue(value string) {
+	m.input.SetValue(value)
+	m.validationErr = ""
+	m.hasError = false
+}
+
+// Update handles input for the number modal.
+func (m *NumberInputModal) Update(msg tea.Msg) (Context, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, m.keys.RestoreDefault):
+			m.setValue(m.defaultVal)
+			return m, nil
+		case key.Matches(msg, m.keys.Increment):
+			m.step(numberSmallStep)
+			return m, nil
+		case key.Matches(msg, m.keys.Decrement):
+			m.step(-numberSmallStep)
+			return m, nil
+		case key.Matches(msg, m.keys.IncrementLarge):
+			m.step(numberLargeStep)
+			return m, nil
+		case key.Matches(msg, m.keys.DecrementLarge):
+			m.step(-numberLargeStep)
+			return m, nil
+		case key.Matches(msg, m.keys.Enter):
+			if err := m.validate(); err != "" && !m.hasError {
+				m.validationErr = err
+				m.hasError = true
+
+				return m, nil
+			}
+
+			m.result = m.input.Value()
+			m.done = true
+
+			return m, func() tea.Msg {
+				return InputResultMsg{Value: m.result}
+			}
+		case key.Matches(msg, m.keys.Escape):
+			if m.hasError {
+				m.validationErr = ""
+				m.hasError = false
+
+				return m, nil
+			}
+
+			m.done = true
+
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+
+	prevValue := m.input.Value()
+	prevPos := m.input.Position()
+	m.input, cmd = m.input.Update(msg)
+
+	// Reject keystrokes and pastes that cannot lead to a number.
+	if !numberPartialPattern.MatchString(m.input.Value()) {
+		m.input.SetValue(prevValue)
+		m.input.SetCursor(prevPos)
+
+		return m, cmd
+	}
+
+	if m.input.Value() != prevValue {
+		m.validationErr = ""
+		m.hasError = false
+	}
+
+	return m, cmd
+}
+
+// View renders the number modal.
+func (m *NumberInputModal) View() string {
+	var s strings.Builder
+
+	s.WriteString(ui.TitleStyle.Render(m.title))
+	s.WriteString("\n")
+
+	if m.description != "" {
+		s.WriteString(ui.SubtitleStyle.Render(m.description))
+		s.WriteString("\n")
+	}
+
+	if m.bounded {
+		s.WriteString("\n")
+		s.WriteString(ui.SubtitleStyle.Render(fmt.Sprintf("Range: %d to %d", m.minVal, m.maxVal)))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
+	s.WriteString(m.input.View())
+	s.WriteString("\n\n")
+
+	defaultDisplay := ui.FormatEmptyValue(m.defaultVal)
+	s.WriteString(ui.SubtitleStyle.Render("Default: " + defaultDisplay))
+	s.WriteString("\n")
+
+	if m.validationErr != "" {
+		s.WriteString("\n")
+		s.WriteString(ui.SelectedStyle.Render("! " + m.validationErr))
+		s.WriteString("\n\n")
+		s.WriteString(ui.HelpStyle.Render("[enter] apply anyway  [esc] keep editing  [ctrl+r] restore default"))
+	} else {
+		s.WriteString("\n")
+		s.WriteString(ui.HelpStyle.Render(
+			"[↑/↓] ±1  [pgup/pgdn] ±10  [enter] confirm  [esc] cancel  [ctrl+r] restore default",
+		))
+	}
+
+	return s.String()
+}
+
+// IsDone returns true if the modal is finished.
+func (m *NumberInputModal) IsDone() bool {
+	return m.done
+}
+
+// Result returns the entered value.
+func (m *NumberInputModal) Result() any {
+	return m.result
+}
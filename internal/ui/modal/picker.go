@@ -0,0 +1,246 @@
+package modal
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/kyleking/gh-lazydispatch/internal/ui"
+)
+
+// pickerModalChrome is the vertical space reserved for the title, source,
+// filter input and help text.
+const pickerModalChrome = 8
+
+// PickerModal presents a long option list that narrows as the user types,
+// for inputs whose options are listed at edit time. It sends SelectResultMsg
+// like SelectModal.
+type PickerModal struct {
+	title        string
+	source       string
+	current      string
+	defaultVal   string
+	result       string
+	keys         pickerKeyMap
+	options      []string
+	matches      []string
+	filter       textinput.Model
+	selected     int
+	scrollOffset int
+	maxHeight    int
+	done         bool
+	loading      bool
+}
+
+type pickerKeyMap struct {
+	Up             key.Binding
+	Down           key.Binding
+	Enter          key.Binding
+	Escape         key.Binding
+	RestoreDefault key.Binding
+}
+
+func defaultPickerKeyMap() pickerKeyMap {
+	return pickerKeyMap{
+		Up:             key.NewBinding(key.WithKeys("up", "ctrl+p")),
+		Down:           key.NewBinding(key.WithKeys("down", "ctrl+n")),
+		Enter:          key.NewBinding(key.WithKeys("enter")),
+		Escape:         key.NewBinding(key.WithKeys("esc")),
+		RestoreDefault: key.NewBinding(key.WithKeys("ctrl+r", "alt+d")),
+	}
+}
+
+// NewPickerModal creates a picker over options, described by source (such as
+// "git tags matching v*"), with current selected.
+func NewPickerModal(title, source string, options []string, current, defaultVal string) *PickerModal {
+	filter := newModalTextInput("")
+	filter.Placeholder = "Type to filter..."
+	filter.Prompt = "/ "
+
+	m := &PickerModal{
+		title:      title,
+		source:     source,
+		current:    current,
+		defaultVal: defaultVal,
+		options:    options,
+		matches:    options,
+		filter:     filter,
+		keys:       defaultPickerKeyMap(),
+		maxHeight:  branchModalInitialHeight - pickerModalChrome,
+	}
+	m.selectValue(current)
+
+	return m
+}
+
+// Loading marks the picker as waiting for its options, which SetOptions
+// supplies. Until then it lists nothing and Enter does nothing.
+func (m *PickerModal) Loading() *PickerModal {
+	m.loading = true
+	return m
+}
+
+// SetOptions replaces the listed options, ending the loading state, and
+// selects the current value if it is among them.
+func (m *PickerModal) SetOptions(options []string) {
+	m.loading = false
+	m.options = options
+	m.matches = ui.ApplyFuzzyFilter(m.filter.Value(), options)
+	m.selectValue(m.current)
+}
+
+// SetSize fits the visible option list to the terminal height.
+func (m *PickerModal) SetSize(_, height int) {
+	maxHeight := min(int(float64(height)*branchModalHeightRatio), branchModalMaxHeight)
+	m.maxHeight = max(maxHeight, branchModalMinHeight) - pickerModalChrome
+	m.adjustScroll()
+}
+
+// Update handles input for the picker modal.
+func (m *PickerModal) Update(msg tea.Msg) (Context, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.keys.Up):
+		if m.selected > 0 {
+			m.selected--
+			m.adjustScroll()
+		}
+	case key.Matches(keyMsg, m.keys.Down):
+		if m.selected < len(m.matches)-1 {
+			m.selected++
+			m.adjustScroll()
+		}
+	case key.Matches(keyMsg, m.keys.RestoreDefault):
+		m.filter.SetValue("")
+		m.matches = m.options
+		m.selectValue(m.defaultVal)
+	case key.Matches(keyMsg, m.keys.Enter):
+		if len(m.matches) == 0 {
+			return m, nil
+		}
+
+		m.result = m.matches[m.selected]
+		m.done = true
+
+		return m, func() tea.Msg {
+			return SelectResultMsg{Value: m.result}
+		}
+	case key.Matches(keyMsg, m.keys.Escape):
+		if m.filter.Value() != "" {
+			m.filter.SetValue("")
+			m.applyFilter()
+
+			return m, nil
+		}
+
+		m.done = true
+	default:
+		var cmd tea.Cmd
+
+		prev := m.filter.Value()
+		m.filter, cmd = m.filter.Update(keyMsg)
+
+		if m.filter.Value() != prev {
+			m.applyFilter()
+		}
+
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+// applyFilter narrows the options to fuzzy matches of the filter, best first.
+func (m *PickerModal) applyFilter() {
+	m.matches = ui.ApplyFuzzyFilter(m.filter.Value(), m.options)
+	m.selected = 0
+	m.scrollOffset = 0
+}
+
+// selectValue moves the cursor to value if it is listed.
+func (m *PickerModal) selectValue(value string) {
+	m.selected = 0
+
+	for i, option := range m.matches {
+		if option == value {
+			m.selected = i
+			break
+		}
+	}
+
+	m.adjustScroll()
+}
+
+func (m *PickerModal) adjustScroll() {
+	if m.selected < m.scrollOffset {
+		m.scrollOffset = m.selected
+	}
+
+	if m.selected >= m.scrollOffset+m.maxHeight {
+		m.scrollOffset = m.selected - m.maxHeight + 1
+	}
+}
+
+// View renders the picker modal.
+func (m *PickerModal) View() string {
+	var s strings.Builder
+
+	s.WriteString(ui.TitleStyle.Render(m.title) + "\n")
+	s.WriteString(ui.SubtitleStyle.Render("Options: "+m.source) + "\n\n")
+	s.WriteString(m.filter.View() + "\n\n")
+
+	switch {
+	case m.loading:
+		s.WriteString(ui.SubtitleStyle.Render("Loading options..."))
+	case len(m.matches) == 0:
+		s.WriteString(ui.SubtitleStyle.Render("No matches"))
+	}
+
+	end := min(m.scrollOffset+m.maxHeight, len(m.matches))
+
+	for i := m.scrollOffset; i < end; i++ {
+		option := m.matches[i]
+		cursor := "  "
+		style := ui.NormalStyle
+
+		if i == m.selected {
+			cursor = "> "
+			style = ui.SelectedStyle
+		}
+
+		if option == m.defaultVal {
+			option += " (default)"
+		}
+
+		s.WriteString(style.Render(cursor + option))
+
+		if i < end-1 {
+			s.WriteString("\n")
+		}
+	}
+
+	if len(m.matches) > m.maxHeight {
+		s.WriteString("\n" + ui.SubtitleStyle.Render(fmt.Sprintf("  %d of %d", m.selected+1, len(m.matches))))
+	}
+
+	s.WriteString("\n\n" + ui.HelpStyle.Render("[↑↓] navigate  [enter] select  [ctrl+r] default  [esc] clear/cancel"))
+
+	return s.String()
+}
+
+// IsDone returns true if the modal is finished.
+func (m *PickerModal) IsDone() bool {
+	return m.done
+}
+
+// Result returns the selected value.
+func (m *PickerModal) Result() any {
+	return m.result
+}
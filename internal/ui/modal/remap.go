@@ -2,6 +2,8 @@ package modal
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -20,12 +22,14 @@ const (
 	RemapActionDrop RemapAction = iota // Drop this input
 	RemapActionKeep                    // Keep with original name (ignore error)
 	RemapActionMap                     // Map to a different input name
+	RemapActionSet                     // Replace the value with NewValue
 )
 
 // RemapDecision represents a user decision for a validation error.
 type RemapDecision struct {
 	OriginalName string
 	NewName      string
+	NewValue     string
 	Action       RemapAction
 }
 
@@ -45,6 +49,7 @@ type RemapModal struct {
 type remapOption struct {
 	label       string
 	targetName  string
+	targetValue string
 	description string
 	action      RemapAction
 }
@@ -90,6 +95,12 @@ func (m *RemapModal) buildOptions() {
 
 	err := m.errors[m.currentErrorIdx]
 	m.options = make([]remapOption, 0)
+	m.selected = 0
+
+	if err.IsValueError() {
+		m.buildValueOptions(err)
+		return
+	}
 
 	// Option 1: Drop this input; Option 2: Keep original (ignore error)
 	m.options = append(m.options,
@@ -116,7 +127,7 @@ func (m *RemapModal) buildOptions() {
 	}
 
 	// Options 4+: Map to any other valid input
-	for name := range m.currentInputs {
+	for _, name := range slices.Sorted(maps.Keys(m.currentInputs)) {
 		if name != err.Suggestion && name != err.HistoricalName {
 			m.options = append(m.options, remapOption{
 				label:       "Map to: " + name,
@@ -126,8 +137,72 @@ func (m *RemapModal) buildOptions() {
 			})
 		}
 	}
+}
 
-	m.selected = 0
+// buildValueOptions lists the options for an input that still exists but
+// whose value no longer fits: the suggested replacement first, then every
+// other choice option, then dropping or keeping the value.
+func (m *RemapModal) buildValueOptions(err validation.ConfigValidationError) {
+	input := m.currentInputs[err.HistoricalName]
+
+	if err.Suggestion != "" {
+		m.options = append(m.options, remapOption{
+			label:       "Use: " + err.Suggestion,
+			action:      RemapActionSet,
+			targetValue: err.Suggestion,
+			description: suggestionDescription(err, input),
+		})
+	}
+
+	if input.InputType() == workflow.InputTypeChoice {
+		for _, option := range input.Options {
+			if option != err.Suggestion {
+				m.options = append(m.options, remapOption{
+					label:       "Use: " + option,
+					action:      RemapActionSet,
+					targetValue: option,
+					description: "Use this option instead",
+				})
+			}
+		}
+	}
+
+	if err.Status == validation.StatusRequiredMissing {
+		m.options = append(m.options, remapOption{
+			label:       "Leave empty",
+			action:      RemapActionKeep,
+			description: "Set it after applying",
+		})
+
+		return
+	}
+
+	m.options = append(m.options,
+		remapOption{
+			label:       "Drop this input",
+			action:      RemapActionDrop,
+			description: "Remove from configuration",
+		},
+		remapOption{
+			label:       "Keep original (ignore error)",
+			action:      RemapActionKeep,
+			description: "Apply configuration as-is",
+		},
+	)
+}
+
+// suggestionDescription explains where a suggested value came from.
+func suggestionDescription(err validation.ConfigValidationError, input workflow.Input) string {
+	switch {
+	case err.Status == validation.StatusRequiredMissing:
+		return "Fill in the required input"
+	case err.Suggestion == input.Default:
+		return "Use the input's default"
+	case err.Status == validation.StatusTypeChanged:
+		return "Converted to the input's current type"
+	}
+
+	return "Closest valid option"
 }
 
 // Update handles input for the remap modal.
@@ -172,6 +247,7 @@ func (m *RemapModal) selectOption() (Context, tea.Cmd) {
 		OriginalName: err.HistoricalName,
 		Action:       opt.action,
 		NewName:      opt.targetName,
+		NewValue:     opt.targetValue,
 	})
 
 	m.currentErrorIdx++
@@ -224,7 +300,14 @@ func (m *RemapModal) View() string {
 
 	statusText := getStatusText(err.Status)
 	s.WriteString(ui.TableItalicStyle.Render(statusText))
-	s.WriteString("\n\n")
+	s.WriteString("\n")
+
+	if err.Details != "" {
+		s.WriteString(ui.SubtitleStyle.Render(err.Details))
+		s.WriteString("\n")
+	}
+
+	s.WriteString("\n")
 
 	// Show options
 	s.WriteString(ui.SubtitleStyle.Render("Choose action:"))
@@ -269,6 +352,12 @@ func getStatusText(status validation.Status) string {
 		return "Input type has changed"
 	case validation.StatusOptionsChanged:
 		return "Value not in valid options"
+	case validation.StatusEnvironmentMissing:
+		return "Environment no longer exists"
+	case validation.StatusRequiredMissing:
+		return "Required input has no value"
+	case validation.StatusRuleFailed:
+		return "Value fails validation"
 	default:
 		return "Unknown error"
 	}
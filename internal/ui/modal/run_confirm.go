@@ -89,19 +89,26 @@ func (m *RunConfirmModal) View() string {
 	s.WriteString(ui.TableDimmedStyle.Render(branch))
 	s.WriteString("\n")
 
-	inputCount := 0
-
-	for _, v := range m.config.Inputs {
-		if v != "" {
-			inputCount++
-		}
-	}
+	payload, err := runner.BuildPayload(m.config)
 
 	s.WriteString(ui.NormalStyle.Render("  Inputs:   "))
-	s.WriteString(ui.TableDimmedStyle.Render(fmt.Sprintf("%d value(s) provided", inputCount)))
+	s.WriteString(ui.TableDimmedStyle.Render(fmt.Sprintf("%d sent, others use their defaults", len(payload.Inputs))))
 	s.WriteString("\n\n")
 
-	s.WriteString(ui.SubtitleStyle.Render("Command:"))
+	s.WriteString(ui.SubtitleStyle.Render("Request body:"))
+	s.WriteString("\n")
+
+	if err != nil {
+		s.WriteString(ui.ErrorStyle.Render("  " + err.Error()))
+	} else {
+		for line := range strings.Lines(runner.FormatPayload(payload)) {
+			s.WriteString(ui.TableDimmedStyle.Render("  " + strings.TrimRight(line, "\n")))
+			s.WriteString("\n")
+		}
+	}
+
+	s.WriteString("\n")
+	s.WriteString(ui.SubtitleStyle.Render("Equivalent command:"))
 	s.WriteString("\n")
 
 	cmd := m.buildCommand()
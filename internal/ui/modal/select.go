@@ -12,13 +12,14 @@ import (
 
 // SelectModal presents a list of options to choose from.
 type SelectModal struct {
-	title      string
-	result     string
-	keys       selectKeyMap
-	options    []string
-	selected   int
-	defaultIdx int
-	done       bool
+	annotations map[string]string
+	title       string
+	result      string
+	keys        selectKeyMap
+	options     []string
+	selected    int
+	defaultIdx  int
+	done        bool
 }
 
 type selectKeyMap struct {
@@ -63,6 +64,12 @@ func NewSelectModal(title string, options []string, current, defaultVal string)
 	}
 }
 
+// WithAnnotations sets a dimmed note rendered after each option, keyed by option value.
+func (m *SelectModal) WithAnnotations(annotations map[string]string) *SelectModal {
+	m.annotations = annotations
+	return m
+}
+
 // Update handles input for the select modal.
 func (m *SelectModal) Update(msg tea.Msg) (Context, tea.Cmd) {
 	if msg, ok := msg.(tea.KeyPressMsg); ok {
@@ -113,6 +120,11 @@ func (m *SelectModal) View() string {
 		}
 
 		s.WriteString(style.Render(fmt.Sprintf("%s%s", cursor, opt)))
+
+		if note := m.annotations[opt]; note != "" {
+			s.WriteString("  " + ui.SubtitleStyle.Render(note))
+		}
+
 		if i < len(m.options)-1 {
 			s.WriteString("\n")
 		}
@@ -676,14 +676,20 @@ func TestTabbedRightModel_TabSwitching(t *testing.T) {
 
 	m.NextTab()
 
+	if m.ActiveTab() != TabPresets {
+		t.Error("expected TabPresets after third NextTab")
+	}
+
+	m.NextTab()
+
 	if m.ActiveTab() != TabHistory {
-		t.Error("expected TabHistory after third NextTab (wrap around)")
+		t.Error("expected TabHistory after fourth NextTab (wrap around)")
 	}
 
 	m.PrevTab()
 
-	if m.ActiveTab() != TabLive {
-		t.Error("expected TabLive after PrevTab")
+	if m.ActiveTab() != TabPresets {
+		t.Error("expected TabPresets after PrevTab")
 	}
 }
 
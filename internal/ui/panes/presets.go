@@ -0,0 +1,162 @@
+package panes
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/kyleking/gh-lazydispatch/internal/config"
+	"github.com/kyleking/gh-lazydispatch/internal/ui"
+)
+
+const (
+	presetNameColWidth   = 16
+	presetInputsColWidth = 6
+	presetDescColWidth   = 24
+)
+
+// PresetListModel manages the list of the selected workflow's presets.
+type PresetListModel struct {
+	presets map[string]config.Preset
+	// drift counts, per preset, the values that no longer fit the workflow.
+	drift         map[string]int
+	presetNames   []string
+	selectedIndex int
+	width         int
+	height        int
+	focused       bool
+}
+
+// NewPresetListModel creates a new preset list model.
+func NewPresetListModel() PresetListModel {
+	return PresetListModel{selectedIndex: 0}
+}
+
+// SetPresets updates the presets and, per preset, how many of its values
+// have drifted from the workflow's current inputs.
+func (m *PresetListModel) SetPresets(presets map[string]config.Preset, drift map[string]int) {
+	m.presets = presets
+	m.drift = drift
+	m.presetNames = make([]string, 0, len(presets))
+
+	for name := range presets {
+		m.presetNames = append(m.presetNames, name)
+	}
+
+	sort.Strings(m.presetNames)
+
+	if m.selectedIndex >= len(m.presetNames) {
+		m.selectedIndex = max(len(m.presetNames)-1, 0)
+	}
+}
+
+// SetSize updates the pane dimensions.
+func (m *PresetListModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetFocused updates the focus state.
+func (m *PresetListModel) SetFocused(focused bool) {
+	m.focused = focused
+}
+
+// MoveUp moves selection up.
+func (m *PresetListModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down.
+func (m *PresetListModel) MoveDown() {
+	if m.selectedIndex < len(m.presetNames)-1 {
+		m.selectedIndex++
+	}
+}
+
+// SelectedPreset returns the currently selected preset.
+func (m PresetListModel) SelectedPreset() (string, config.Preset, bool) {
+	if len(m.presetNames) == 0 {
+		return "", config.Preset{}, false
+	}
+
+	name := m.presetNames[m.selectedIndex]
+
+	return name, m.presets[name], true
+}
+
+// Update handles messages for the preset list.
+func (m PresetListModel) Update(_ tea.Msg) (PresetListModel, tea.Cmd) {
+	return m, nil
+}
+
+// ViewContent renders the preset list content without the pane border.
+func (m PresetListModel) ViewContent() string {
+	var content strings.Builder
+
+	if len(m.presetNames) == 0 {
+		content.WriteString(ui.SubtitleStyle.Render("No presets for this workflow"))
+		content.WriteString("\n\n")
+		content.WriteString(ui.NormalStyle.Render("Press S in the config pane to save"))
+		content.WriteString("\n")
+		content.WriteString(ui.NormalStyle.Render("the current inputs, or add them to"))
+		content.WriteString("\n")
+		content.WriteString(ui.HelpStyle.Render(".github/lazydispatch.yml:"))
+		content.WriteString("\n\n")
+		content.WriteString(ui.CLIPreviewStyle.Render("  workflows:"))
+		content.WriteString("\n")
+		content.WriteString(ui.CLIPreviewStyle.Render("    deploy.yml:"))
+		content.WriteString("\n")
+		content.WriteString(ui.CLIPreviewStyle.Render("      presets:"))
+		content.WriteString("\n")
+		content.WriteString(ui.CLIPreviewStyle.Render("        staging-canary:"))
+		content.WriteString("\n")
+		content.WriteString(ui.CLIPreviewStyle.Render("          inputs: {environment: staging}"))
+
+		return content.String()
+	}
+
+	content.WriteString(ui.TableHeaderStyle.Render(
+		"  Name              Inputs  Description"))
+	content.WriteString("\n")
+
+	for i, name := range m.presetNames {
+		preset := m.presets[name]
+
+		displayName := ui.TruncateWithEllipsis(name, presetNameColWidth)
+		inputs := strconv.Itoa(len(preset.Inputs))
+
+		desc := ui.TruncateWithEllipsis(preset.Description, presetDescColWidth)
+		if desc == "" {
+			desc = "(no description)"
+		}
+
+		if n := m.drift[name]; n > 0 {
+			desc = "! " + strconv.Itoa(n) + " outdated  " + desc
+		}
+
+		indicator := "  "
+		if i == m.selectedIndex {
+			indicator = "> "
+		}
+
+		row := indicator + ui.PadRight(displayName, presetNameColWidth) + "  " +
+			ui.PadRight(inputs, presetInputsColWidth) + "  " + desc
+
+		rowStyle := ui.TableRowStyle
+		if i == m.selectedIndex {
+			rowStyle = ui.TableSelectedStyle
+		}
+
+		content.WriteString(rowStyle.Render(row))
+
+		if i < len(m.presetNames)-1 {
+			content.WriteString("\n")
+		}
+	}
+
+	return content.String()
+}
@@ -19,6 +19,7 @@ const (
 	TabHistory RightTab = iota
 	TabChains
 	TabLive
+	TabPresets
 )
 
 // TabbedRightModel manages the tabbed right panel.
@@ -26,6 +27,7 @@ type TabbedRightModel struct {
 	history   HistoryModel
 	chains    ChainListModel
 	live      LiveRunsModel
+	presets   PresetListModel
 	activeTab RightTab
 	width     int
 	height    int
@@ -39,6 +41,7 @@ func NewTabbedRight() TabbedRightModel {
 		history:   NewHistoryModel(),
 		chains:    NewChainListModel(),
 		live:      NewLiveRunsModel(),
+		presets:   NewPresetListModel(),
 	}
 }
 
@@ -56,6 +59,7 @@ func (m *TabbedRightModel) SetSize(width, height int) {
 	m.history.SetSize(width-tabbedChromeWidth, contentHeight)
 	m.chains.SetSize(width-tabbedChromeWidth, contentHeight)
 	m.live.SetSize(width-tabbedChromeWidth, contentHeight)
+	m.presets.SetSize(width-tabbedChromeWidth, contentHeight)
 }
 
 // SetFocused updates the focus state.
@@ -64,6 +68,7 @@ func (m *TabbedRightModel) SetFocused(focused bool) {
 	m.history.SetFocused(focused && m.activeTab == TabHistory)
 	m.chains.SetFocused(focused && m.activeTab == TabChains)
 	m.live.SetFocused(focused && m.activeTab == TabLive)
+	m.presets.SetFocused(focused && m.activeTab == TabPresets)
 }
 
 // ActiveTab returns the currently active tab.
@@ -72,7 +77,7 @@ func (m TabbedRightModel) ActiveTab() RightTab {
 }
 
 // tabCount is the number of tabs in the right panel.
-const tabCount = 3
+const tabCount = 4
 
 // NextTab switches to the next tab.
 func (m *TabbedRightModel) NextTab() {
@@ -90,6 +95,7 @@ func (m *TabbedRightModel) updateTabFocus() {
 	m.history.SetFocused(m.focused && m.activeTab == TabHistory)
 	m.chains.SetFocused(m.focused && m.activeTab == TabChains)
 	m.live.SetFocused(m.focused && m.activeTab == TabLive)
+	m.presets.SetFocused(m.focused && m.activeTab == TabPresets)
 }
 
 // SetHistoryEntries updates the history entries.
@@ -107,6 +113,11 @@ func (m *TabbedRightModel) SetRuns(runs []watcher.WatchedRun) {
 	m.live.SetRuns(runs)
 }
 
+// SetPresets updates the selected workflow's presets and their drift counts.
+func (m *TabbedRightModel) SetPresets(presets map[string]config.Preset, drift map[string]int) {
+	m.presets.SetPresets(presets, drift)
+}
+
 // History returns the history model for direct access.
 func (m *TabbedRightModel) History() *HistoryModel {
 	return &m.history
@@ -122,6 +133,11 @@ func (m *TabbedRightModel) Live() *LiveRunsModel {
 	return &m.live
 }
 
+// Presets returns the preset list model for direct access.
+func (m *TabbedRightModel) Presets() *PresetListModel {
+	return &m.presets
+}
+
 // Update handles messages for the active tab.
 func (m TabbedRightModel) Update(msg tea.Msg) (TabbedRightModel, tea.Cmd) {
 	if !m.focused {
@@ -137,6 +153,8 @@ func (m TabbedRightModel) Update(msg tea.Msg) (TabbedRightModel, tea.Cmd) {
 		m.chains, cmd = m.chains.Update(msg)
 	case TabLive:
 		m.live, cmd = m.live.Update(msg)
+	case TabPresets:
+		m.presets, cmd = m.presets.Update(msg)
 	}
 
 	return m, cmd
@@ -157,6 +175,8 @@ func (m TabbedRightModel) View() string {
 		content = m.chains.ViewContent()
 	case TabLive:
 		content = m.live.ViewContent()
+	case TabPresets:
+		content = m.presets.ViewContent()
 	}
 
 	return style.Render(tabs + "\n" + content)
@@ -170,6 +190,7 @@ func (m TabbedRightModel) renderTabHeader() string {
 		{"History", TabHistory},
 		{"Chains", TabChains},
 		{"Live", TabLive},
+		{"Presets", TabPresets},
 	}
 
 	var parts []string
@@ -195,6 +216,11 @@ func (m TabbedRightModel) SelectedChain() (string, config.Chain, bool) {
 	return m.chains.SelectedChain()
 }
 
+// SelectedPreset returns the currently selected preset.
+func (m TabbedRightModel) SelectedPreset() (string, config.Preset, bool) {
+	return m.presets.SelectedPreset()
+}
+
 // SelectedRun returns the currently selected run.
 func (m TabbedRightModel) SelectedRun() (watcher.WatchedRun, bool) {
 	return m.live.SelectedRun()
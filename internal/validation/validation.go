@@ -2,11 +2,15 @@
 package validation
 
 import (
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/sahilm/fuzzy"
 
 	"github.com/kyleking/gh-lazydispatch/internal/frecency"
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
 	"github.com/kyleking/gh-lazydispatch/internal/workflow"
 )
 
@@ -17,32 +21,71 @@ const inputTypeChoice = "choice"
 
 // Validation status values.
 const (
-	StatusValid          Status = iota // Input is valid
-	StatusMissing                      // Input name no longer exists
-	StatusTypeChanged                  // Input type has changed
-	StatusOptionsChanged               // Value not in choice options
+	StatusValid              Status = iota // Input is valid
+	StatusMissing                          // Input name no longer exists
+	StatusTypeChanged                      // Input type has changed
+	StatusOptionsChanged                   // Value not in choice options
+	StatusEnvironmentMissing               // Environment no longer exists in the repository
+	StatusRequiredMissing                  // Input is now required but has no value
+	StatusRuleFailed                       // Value fails the input's validation rules
 )
 
 // ConfigValidationError represents a validation error for a historical input.
+// Suggestion is a current input name for StatusMissing, and a replacement
+// value for every other status.
 type ConfigValidationError struct {
 	HistoricalName  string
 	HistoricalValue string
 	Suggestion      string
-	Status          Status
+	// Details holds the failed rule messages for StatusRuleFailed.
+	Details string
+	Status  Status
 }
 
-// ValidateHistoryConfig validates a historical configuration against current workflow inputs.
-// Returns a list of validation errors, or nil if all inputs are valid.
+// IsValueError returns true if the input still exists but its value needs
+// replacing, so Suggestion is a value rather than an input name.
+func (e ConfigValidationError) IsValueError() bool {
+	return e.Status != StatusMissing && e.Status != StatusValid
+}
+
+// ValidateHistoryConfig validates a historical configuration against current workflow inputs:
+// renamed inputs, values that no longer fit the input's type or options, values failing the
+// current validation rules, and required inputs without a value.
+// Returns the errors for historical inputs in name order, then those for required inputs,
+// or nil if all inputs are valid.
 func ValidateHistoryConfig(entry *frecency.HistoryEntry, wf *workflow.File) []ConfigValidationError {
+	return ValidateHistoryConfigWithEnvironments(entry, wf, nil)
+}
+
+// ValidateHistoryConfigWithEnvironments validates like ValidateHistoryConfig and
+// additionally flags environment-type inputs whose value is not one of environments.
+// A nil environments slice means the repository's environments are unknown and skips that check.
+func ValidateHistoryConfigWithEnvironments(
+	entry *frecency.HistoryEntry, wf *workflow.File, environments []string,
+) []ConfigValidationError {
 	if entry == nil || wf == nil {
 		return nil
 	}
 
+	return ValidateInputs(entry.Inputs, wf, environments)
+}
+
+// ValidateInputs checks saved input values, such as a history entry's or a
+// preset's, against wf's current inputs, as ValidateHistoryConfigWithEnvironments does.
+func ValidateInputs(values map[string]string, wf *workflow.File, environments []string) []ConfigValidationError {
 	var errors []ConfigValidationError
 
 	currentInputs := wf.GetInputs()
 
-	for historicalName, historicalValue := range entry.Inputs {
+	historicalNames := make([]string, 0, len(values))
+	for name := range values {
+		historicalNames = append(historicalNames, name)
+	}
+
+	sort.Strings(historicalNames)
+
+	for _, historicalName := range historicalNames {
+		historicalValue := values[historicalName]
 		currentInput, exists := currentInputs[historicalName]
 
 		if !exists {
@@ -61,38 +104,190 @@ func ValidateHistoryConfig(entry *frecency.HistoryEntry, wf *workflow.File) []Co
 		// Input exists - validate value compatibility
 		if err := validateInputValue(historicalName, historicalValue, currentInput); err != nil {
 			errors = append(errors, *err)
+			continue
+		}
+
+		if err := validateEnvironmentValue(historicalName, historicalValue, currentInput, environments); err != nil {
+			errors = append(errors, *err)
+			continue
+		}
+
+		if err := validateRules(historicalName, historicalValue, currentInput, values); err != nil {
+			errors = append(errors, *err)
+		}
+	}
+
+	for _, err := range findRequiredMissing(values, currentInputs) {
+		if !slices.ContainsFunc(errors, func(e ConfigValidationError) bool { return e.HistoricalName == err.HistoricalName }) {
+			errors = append(errors, err)
 		}
 	}
 
 	return errors
 }
 
-// validateInputValue checks if a historical value is compatible with the current input definition.
+// validateInputValue checks if a historical value is compatible with the
+// current input's type: booleans must be "true" or "false", numbers must
+// parse, and choices must be one of the options. Empty values are left to
+// findRequiredMissing.
 func validateInputValue(name, value string, input workflow.Input) *ConfigValidationError {
-	// For choice inputs, validate that the value is still in the options
-	if input.Type == inputTypeChoice && len(input.Options) > 0 {
-		validOption := false
-
-		for _, option := range input.Options {
-			if value == option {
-				validOption = true
-				break
-			}
+	if value == "" {
+		return nil
+	}
+
+	status := StatusValid
+
+	switch input.InputType() {
+	case workflow.InputTypeBoolean:
+		if value != "true" && value != "false" {
+			status = StatusTypeChanged
+		}
+	case workflow.InputTypeNumber:
+		if !workflow.IsNumber(value) {
+			status = StatusTypeChanged
+		}
+	case workflow.InputTypeChoice:
+		if len(input.Options) > 0 && !slices.Contains(input.Options, value) {
+			status = StatusOptionsChanged
+		}
+	case workflow.InputTypeString, workflow.InputTypeEnvironment:
+	}
+
+	if status == StatusValid {
+		return nil
+	}
+
+	suggestion := CoerceValue(value, input)
+	if suggestion == "" {
+		suggestion = input.Default
+	}
+
+	return &ConfigValidationError{
+		HistoricalName:  name,
+		HistoricalValue: value,
+		Status:          status,
+		Suggestion:      suggestion,
+	}
+}
+
+// CoerceValue converts value to fit input's type, such as "yes" to "true"
+// for a boolean or "1,000" to "1000" for a number, or picks the closest
+// choice option. Returns "" when there is no sensible conversion.
+func CoerceValue(value string, input workflow.Input) string {
+	trimmed := strings.TrimSpace(value)
+
+	switch input.InputType() {
+	case workflow.InputTypeBoolean:
+		switch strings.ToLower(trimmed) {
+		case "true", "t", "yes", "y", "on", "1", "enabled":
+			return "true"
+		case "false", "f", "no", "n", "off", "0", "disabled":
+			return "false"
 		}
+	case workflow.InputTypeNumber:
+		cleaned := strings.NewReplacer(",", "", "_", "").Replace(trimmed)
+		if _, err := strconv.ParseFloat(cleaned, 64); err == nil {
+			return cleaned
+		}
+	case workflow.InputTypeChoice:
+		return closestOption(trimmed, input.Options)
+	case workflow.InputTypeString, workflow.InputTypeEnvironment:
+	}
+
+	return ""
+}
 
-		if !validOption {
-			return &ConfigValidationError{
-				HistoricalName:  name,
-				HistoricalValue: value,
-				Status:          StatusOptionsChanged,
-				Suggestion:      input.Default, // Suggest the current default
+// closestOption returns the option equal to value ignoring case, otherwise
+// the best fuzzy match, or "" if nothing matches.
+func closestOption(value string, options []string) string {
+	for _, option := range options {
+		if strings.EqualFold(option, value) {
+			return option
+		}
+	}
+
+	if matches := fuzzy.Find(value, options); len(matches) > 0 {
+		return matches[0].Str
+	}
+
+	return ""
+}
+
+// validateRules checks a historical value against the input's current
+// validation rules, with inputs supplying the values cross-field rules compare.
+func validateRules(name, value string, input workflow.Input, inputs map[string]string) *ConfigValidationError {
+	messages := rule.ValidateValue(value, input.ValidationRules, inputs)
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return &ConfigValidationError{
+		HistoricalName:  name,
+		HistoricalValue: value,
+		Status:          StatusRuleFailed,
+		Details:         strings.Join(messages, "; "),
+	}
+}
+
+// findRequiredMissing flags required inputs that have neither a historical
+// value nor a default, in name order. Suggestion is a value that satisfies
+// the input's type, when one is obvious.
+func findRequiredMissing(values map[string]string, currentInputs map[string]workflow.Input) []ConfigValidationError {
+	var errors []ConfigValidationError
+
+	for _, name := range sortedNames(currentInputs) {
+		input := currentInputs[name]
+		if !input.Required || input.Default != "" || values[name] != "" {
+			continue
+		}
+
+		suggestion := ""
+
+		switch input.InputType() {
+		case workflow.InputTypeBoolean:
+			suggestion = "false"
+		case workflow.InputTypeChoice:
+			if len(input.Options) > 0 {
+				suggestion = input.Options[0]
 			}
+		case workflow.InputTypeString, workflow.InputTypeNumber, workflow.InputTypeEnvironment:
 		}
+
+		errors = append(errors, ConfigValidationError{
+			HistoricalName: name,
+			Status:         StatusRequiredMissing,
+			Suggestion:     suggestion,
+		})
 	}
 
-	// Could add more type-specific validations here (boolean, number, etc.)
+	return errors
+}
+
+// validateEnvironmentValue checks that an environment-type input still names an existing environment.
+func validateEnvironmentValue(
+	name, value string, input workflow.Input, environments []string,
+) *ConfigValidationError {
+	if environments == nil || input.InputType() != workflow.InputTypeEnvironment || value == "" {
+		return nil
+	}
 
-	return nil
+	if slices.Contains(environments, value) {
+		return nil
+	}
+
+	suggestion := ""
+	if matches := fuzzy.Find(value, environments); len(matches) > 0 {
+		suggestion = matches[0].Str
+	} else if slices.Contains(environments, input.Default) {
+		suggestion = input.Default
+	}
+
+	return &ConfigValidationError{
+		HistoricalName:  name,
+		HistoricalValue: value,
+		Status:          StatusEnvironmentMissing,
+		Suggestion:      suggestion,
+	}
 }
 
 // findBestMatch uses fuzzy matching to find the most similar input name.
@@ -103,12 +298,7 @@ func findBestMatch(historicalName string, currentInputs map[string]workflow.Inpu
 	}
 
 	// Build list of current input names
-	names := make([]string, 0, len(currentInputs))
-	for name := range currentInputs {
-		names = append(names, name)
-	}
-
-	sort.Strings(names)
+	names := sortedNames(currentInputs)
 
 	// Use fuzzy matching to find similar names
 	matches := fuzzy.Find(historicalName, names)
@@ -128,3 +318,15 @@ func findBestMatch(historicalName string, currentInputs map[string]workflow.Inpu
 
 	return ""
 }
+
+// sortedNames returns the input names in alphabetical order.
+func sortedNames(inputs map[string]workflow.Input) []string {
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -1,9 +1,11 @@
 package validation
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/kyleking/gh-lazydispatch/internal/frecency"
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
 	"github.com/kyleking/gh-lazydispatch/internal/workflow"
 )
 
@@ -294,6 +296,36 @@ func TestValidateInputValue(t *testing.T) {
 			input:     workflow.Input{Type: "boolean"},
 			wantError: false,
 		},
+		{
+			name:       "boolean type changed",
+			inputName:  "debug",
+			value:      "yes",
+			input:      workflow.Input{Type: "boolean"},
+			wantError:  true,
+			wantStatus: StatusTypeChanged,
+		},
+		{
+			name:       "number type changed",
+			inputName:  "replicas",
+			value:      "three",
+			input:      workflow.Input{Type: "number"},
+			wantError:  true,
+			wantStatus: StatusTypeChanged,
+		},
+		{
+			name:      "number valid",
+			inputName: "replicas",
+			value:     "2.5",
+			input:     workflow.Input{Type: "number"},
+			wantError: false,
+		},
+		{
+			name:      "empty value left to required check",
+			inputName: "debug",
+			value:     "",
+			input:     workflow.Input{Type: "boolean"},
+			wantError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -312,3 +344,124 @@ func TestValidateInputValue(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateHistoryConfigWithEnvironments(t *testing.T) {
+	t.Parallel()
+
+	wf := &workflow.File{
+		On: workflow.OnTrigger{
+			Dispatch: &workflow.Dispatch{
+				Inputs: map[string]workflow.Input{
+					"target": {Type: "environment", Default: "staging"},
+					"note":   {Type: "string"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		inputs         map[string]string
+		environments   []string
+		wantErrors     int
+		wantSuggestion string
+	}{
+		{"environment exists", map[string]string{"target": "staging"}, []string{"staging", "production"}, 0, ""},
+		{"environment deleted", map[string]string{"target": "qa"}, []string{"staging", "production"}, 1, "staging"},
+		{"fuzzy suggestion", map[string]string{"target": "prod"}, []string{"staging", "production"}, 1, "production"},
+		{"environments unknown", map[string]string{"target": "qa"}, nil, 0, ""},
+		{"string input ignored", map[string]string{"note": "qa"}, []string{"staging"}, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			entry := &frecency.HistoryEntry{Inputs: tt.inputs}
+			errs := ValidateHistoryConfigWithEnvironments(entry, wf, tt.environments)
+
+			if len(errs) != tt.wantErrors {
+				t.Fatalf("errors = %d, want %d: %v", len(errs), tt.wantErrors, errs)
+			}
+
+			if tt.wantErrors == 0 {
+				return
+			}
+
+			if errs[0].Status != StatusEnvironmentMissing {
+				t.Errorf("Status = %v, want StatusEnvironmentMissing", errs[0].Status)
+			}
+
+			if errs[0].Suggestion != tt.wantSuggestion {
+				t.Errorf("Suggestion = %q, want %q", errs[0].Suggestion, tt.wantSuggestion)
+			}
+		})
+	}
+}
+
+func TestValidateHistoryConfig_Drift(t *testing.T) {
+	t.Parallel()
+
+	versionRule := rule.ValidationRule{Type: rule.RulePrefix, Pattern: "v"}
+	wf := &workflow.File{On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{
+		Inputs: map[string]workflow.Input{
+			"debug":   {Type: "boolean"},
+			"env":     {Type: "choice", Options: []string{"production", "staging"}},
+			"region":  {Type: "string", Required: true},
+			"retries": {Type: "number", Required: true, Default: "3"},
+			"version": {Type: "string", ValidationRules: []rule.ValidationRule{versionRule}},
+		},
+	}}}
+
+	entry := &frecency.HistoryEntry{Inputs: map[string]string{
+		"debug":   "yes",
+		"env":     "Prod",
+		"version": "1.2.0",
+	}}
+
+	want := []ConfigValidationError{
+		{HistoricalName: "debug", HistoricalValue: "yes", Status: StatusTypeChanged, Suggestion: "true"},
+		{HistoricalName: "env", HistoricalValue: "Prod", Status: StatusOptionsChanged, Suggestion: "production"},
+		{
+			HistoricalName: "version", HistoricalValue: "1.2.0", Status: StatusRuleFailed,
+			Details: "must start with: v (prefix:v)",
+		},
+		{HistoricalName: "region", Status: StatusRequiredMissing},
+	}
+
+	errs := ValidateHistoryConfig(entry, wf)
+	if !slices.Equal(errs, want) {
+		t.Errorf("ValidateHistoryConfig() =\n%+v\nwant\n%+v", errs, want)
+	}
+}
+
+func TestCoerceValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		input workflow.Input
+		want  string
+	}{
+		{"boolean yes", "yes", workflow.Input{Type: "boolean"}, "true"},
+		{"boolean off", " OFF ", workflow.Input{Type: "boolean"}, "false"},
+		{"boolean unknown", "maybe", workflow.Input{Type: "boolean"}, ""},
+		{"number separators", "1,000", workflow.Input{Type: "number"}, "1000"},
+		{"number words", "ten", workflow.Input{Type: "number"}, ""},
+		{"choice case", "STAGING", workflow.Input{Type: "choice", Options: []string{"production", "staging"}}, "staging"},
+		{"choice fuzzy", "prod", workflow.Input{Type: "choice", Options: []string{"production", "staging"}}, "production"},
+		{"choice none", "qa", workflow.Input{Type: "choice", Options: []string{"production", "staging"}}, ""},
+		{"string unchanged", "anything", workflow.Input{Type: "string"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := CoerceValue(tt.value, tt.input); got != tt.want {
+				t.Errorf("CoerceValue(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// cacheVersion is stored in each cache file; files written with a different
+// version are ignored. Bump it whenever File or Parse changes shape.
+const cacheVersion = 7
+
+const (
+	cacheDirPerm  = 0o750
+	cacheFilePerm = 0o600
+)
+
+// Cache persists parsed workflow files on disk, keyed by path, size and
+// modification time, so a warm start only re-parses files that changed.
+// Each repository has its own cache file, and saving keeps only the entries
+// used since the cache was opened, so deleted workflows drop out.
+type Cache struct {
+	entries map[string]cacheEntry
+	used    map[string]cacheEntry
+	path    string
+	mu      sync.Mutex
+}
+
+// cacheEntry is one parsed workflow and the file stamp it was parsed from.
+type cacheEntry struct {
+	ModTime time.Time `json:"mod_time"`
+	File    File      `json:"file"`
+	Size    int64     `json:"size"`
+}
+
+// cacheFile is the on-disk form of a Cache.
+type cacheFile struct {
+	Entries map[string]cacheEntry `json:"entries"`
+	Version int                   `json:"version"`
+}
+
+// NewCache opens the cache for the repository at repoRoot, stored under
+// cacheDir (something like ~/.cache/lazydispatch/workflows/). A missing,
+// unreadable or outdated cache file starts an empty cache.
+func NewCache(cacheDir, repoRoot string) *Cache {
+	if abs, err := filepath.Abs(repoRoot); err == nil {
+		repoRoot = abs
+	}
+
+	sum := sha256.Sum256([]byte(repoRoot))
+
+	c := &Cache{
+		path:    filepath.Join(cacheDir, hex.EncodeToString(sum[:8])+".json"),
+		entries: make(map[string]cacheEntry),
+		used:    make(map[string]cacheEntry),
+	}
+
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+
+	var stored cacheFile
+	if err := json.Unmarshal(data, &stored); err == nil && stored.Version == cacheVersion && stored.Entries != nil {
+		c.entries = stored.Entries
+	}
+
+	return c
+}
+
+// get returns the cached parse of path if its size and modification time
+// still match info.
+func (c *Cache) get(path string, info os.FileInfo) (File, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[path]
+	if !ok || entry.Size != info.Size() || !entry.ModTime.Equal(info.ModTime()) {
+		return File{}, false
+	}
+
+	c.used[path] = entry
+
+	return entry.File, true
+}
+
+// put records the parse of path at the version described by info.
+func (c *Cache) put(path string, info os.FileInfo, wf File) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := cacheEntry{File: wf, Size: info.Size(), ModTime: info.ModTime()}
+	c.entries[path] = entry
+	c.used[path] = entry
+}
+
+// Save writes the entries used since the cache was opened to disk.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.Marshal(cacheFile{Version: cacheVersion, Entries: c.used})
+	if err != nil {
+		return fmt.Errorf("failed to marshal workflow cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), cacheDirPerm); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
+	}
+
+	if err := os.WriteFile(c.path, data, cacheFilePerm); err != nil {
+		return fmt.Errorf("failed to write workflow cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	pathpkg "path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// localCallPrefix starts a uses: reference to a workflow in the same repository.
+const localCallPrefix = "./"
+
+// inputRefPattern matches references to an input in an expression, such as
+// inputs.version, inputs['version'] or github.event.inputs.version.
+var inputRefPattern = regexp.MustCompile(`(?:github\.event\.)?\binputs(?:\.([A-Za-z_][\w-]*)|\[\s*'([^']+)'\s*\])`)
+
+// ReusableCall is a job that runs a reusable workflow through jobs.<id>.uses.
+type ReusableCall struct {
+	// With holds the inputs the job passes to the called workflow.
+	With map[string]string
+	// Calls holds the called workflow's own reusable calls.
+	Calls []ReusableCall
+	JobID string
+	Uses  string
+	// Name is the called workflow's name, or its filename if it has none.
+	Name string
+	// Resolved is false for references to other repositories and for local
+	// files that are missing or do not declare workflow_call.
+	Resolved bool
+}
+
+// IsLocal returns true if the call references a workflow in the same repository.
+func (c ReusableCall) IsLocal() bool {
+	return strings.HasPrefix(c.Uses, localCallPrefix)
+}
+
+// InputsNotPassed returns the sorted names of dispatch inputs that no direct
+// reusable call passes through with:. It returns nil for workflows that call
+// no reusable workflows.
+func (w File) InputsNotPassed() []string {
+	if len(w.Calls) == 0 {
+		return nil
+	}
+
+	passed := make(map[string]bool)
+
+	for _, call := range w.Calls {
+		for _, value := range call.With {
+			for _, name := range referencedInputs(value) {
+				passed[name] = true
+			}
+		}
+	}
+
+	var missing []string
+
+	for name := range w.GetInputs() {
+		if !passed[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
+
+// resolveCalls sets Calls on each dispatchable workflow, resolving local uses:
+// references against every workflow in the report.
+func resolveCalls(report *DiscoveryReport) {
+	byFilename := make(map[string]File)
+
+	for _, files := range [][]File{report.Dispatchable, report.NonDispatchable} {
+		for _, wf := range files {
+			byFilename[wf.Filename] = wf
+		}
+	}
+
+	for i := range report.Dispatchable {
+		wf := &report.Dispatchable[i]
+		wf.Calls = callTree(*wf, byFilename, map[string]bool{wf.Filename: true})
+	}
+}
+
+// callTree returns the reusable calls of wf, descending into resolved local
+// workflows. visiting holds the workflows on the current path, so a cycle
+// stops instead of recursing forever.
+func callTree(wf File, byFilename map[string]File, visiting map[string]bool) []ReusableCall {
+	var calls []ReusableCall
+
+	for _, job := range wf.Jobs {
+		if job.Uses == "" {
+			continue
+		}
+
+		call := ReusableCall{JobID: job.ID, Uses: job.Uses, With: job.With, Name: job.Uses}
+
+		if call.IsLocal() {
+			filename := pathpkg.Base(job.Uses)
+			if called, ok := byFilename[filename]; ok && called.IsReusable() {
+				call.Resolved = true
+				call.Name = called.Name
+
+				if call.Name == "" {
+					call.Name = filename
+				}
+
+				if !visiting[filename] {
+					visiting[filename] = true
+					call.Calls = callTree(called, byFilename, visiting)
+					visiting[filename] = false
+				}
+			}
+		}
+
+		calls = append(calls, call)
+	}
+
+	return calls
+}
+
+// referencedInputs returns the distinct input names referenced in value, in order.
+func referencedInputs(value string) []string {
+	var names []string
+
+	for _, match := range inputRefPattern.FindAllStringSubmatch(value, -1) {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,139 @@
+package workflow_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+func TestDiscoverFrom_ResolvesReusableCalls(t *testing.T) {
+	t.Parallel()
+
+	report, err := workflow.DiscoverFrom(mapSource{
+		".github/workflows/release.yml": `name: Release
+on:
+  workflow_dispatch:
+    inputs:
+      environment: {}
+      version: {}
+      dry_run: {type: boolean}
+jobs:
+  deploy:
+    uses: ./.github/workflows/deploy.yml
+    with:
+      environment: ${{ inputs.environment }}
+      tag: v${{ github.event.inputs['version'] }}
+      region: us-east-1
+  lint:
+    uses: octo-org/shared/.github/workflows/lint.yml@v1
+  missing:
+    uses: ./.github/workflows/missing.yml
+  test:
+    runs-on: ubuntu-latest
+`,
+		".github/workflows/deploy.yml": `name: Deploy
+on:
+  workflow_call:
+    inputs:
+      environment: {type: string, required: true}
+jobs:
+  notify:
+    uses: ./.github/workflows/notify.yml
+`,
+		".github/workflows/notify.yml": `on:
+  workflow_call:
+jobs:
+  again:
+    uses: ./.github/workflows/deploy.yml
+`,
+	})
+	if err != nil {
+		t.Fatalf("DiscoverFrom failed: %v", err)
+	}
+
+	if len(report.Dispatchable) != 1 {
+		t.Fatalf("expected 1 dispatchable workflow, got %d", len(report.Dispatchable))
+	}
+
+	calls := report.Dispatchable[0].Calls
+	if len(calls) != 3 {
+		t.Fatalf("expected 3 calls, got %+v", calls)
+	}
+
+	deploy, lint, missing := calls[0], calls[1], calls[2]
+
+	if !deploy.Resolved || deploy.Name != "Deploy" || deploy.With["region"] != "us-east-1" {
+		t.Errorf("unexpected deploy call %+v", deploy)
+	}
+
+	if len(deploy.Calls) != 1 || deploy.Calls[0].Name != "notify.yml" {
+		t.Fatalf("expected nested notify call, got %+v", deploy.Calls)
+	}
+
+	// notify calls deploy again; the cycle is listed but not expanded.
+	if again := deploy.Calls[0].Calls; len(again) != 1 || len(again[0].Calls) != 0 {
+		t.Errorf("expected the cycle to stop after one level, got %+v", again)
+	}
+
+	if lint.Resolved || lint.IsLocal() {
+		t.Errorf("expected remote call to be unresolved, got %+v", lint)
+	}
+
+	if missing.Resolved || !missing.IsLocal() {
+		t.Errorf("expected missing local call to be unresolved, got %+v", missing)
+	}
+
+	if got := report.Dispatchable[0].InputsNotPassed(); !slices.Equal(got, []string{"dry_run"}) {
+		t.Errorf("expected dry_run not passed, got %v", got)
+	}
+}
+
+func TestInputsNotPassed_NoCalls(t *testing.T) {
+	t.Parallel()
+
+	wf, err := workflow.Parse([]byte("on:\n  workflow_dispatch:\n    inputs:\n      a: {}\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if got := wf.InputsNotPassed(); got != nil {
+		t.Errorf("expected nil without calls, got %v", got)
+	}
+}
+
+func TestParse_Triggers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		yaml         string
+		wantDispatch bool
+		wantCall     bool
+	}{
+		{"scalar call", "on: workflow_call\n", false, true},
+		{"list", "on: [push, workflow_call, workflow_dispatch]\n", true, true},
+		{"bare call key", "on:\n  workflow_call:\n  push:\n", false, true},
+		{"bare dispatch key", "on:\n  workflow_dispatch:\n  push:\n", true, false},
+		{"null dispatch key", "on:\n  workflow_dispatch: null\n", true, false},
+		{"bare keys", "on:\n  workflow_dispatch:\n  workflow_call:\n  push:\n", true, true},
+		{"call inputs", "on:\n  workflow_call:\n    inputs:\n      env: {type: string}\n", false, true},
+		{"push only", "on:\n  push:\n", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			wf, err := workflow.Parse([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("Parse failed: %v", err)
+			}
+
+			if wf.IsDispatchable() != tt.wantDispatch || wf.IsReusable() != tt.wantCall {
+				t.Errorf("dispatchable=%v reusable=%v, want %v %v",
+					wf.IsDispatchable(), wf.IsReusable(), tt.wantDispatch, tt.wantCall)
+			}
+		})
+	}
+}
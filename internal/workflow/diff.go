@@ -0,0 +1,67 @@
+package workflow
+
+import "slices"
+
+// InputChange describes how an input differs between two versions of a workflow.
+type InputChange int
+
+// Input change kinds, relative to the base version.
+const (
+	InputUnchanged InputChange = iota
+	InputAdded                 // Present only in the target version
+	InputRemoved               // Present only in the base version
+	InputModified              // Type, default, required flag or options differ
+)
+
+// String returns a short label for the change, as shown in the config pane.
+func (c InputChange) String() string {
+	switch c {
+	case InputAdded:
+		return "added"
+	case InputRemoved:
+		return "removed"
+	case InputModified:
+		return "changed"
+	case InputUnchanged:
+	}
+
+	return ""
+}
+
+// DiffInputs compares the inputs of base and target, typically the local
+// working copy of a workflow and the same workflow at another ref.
+// Unchanged inputs are omitted, so an empty map means the inputs match.
+func DiffInputs(base, target File) map[string]InputChange {
+	baseInputs := base.GetInputs()
+	targetInputs := target.GetInputs()
+
+	changes := make(map[string]InputChange)
+
+	for name, targetInput := range targetInputs {
+		baseInput, ok := baseInputs[name]
+
+		switch {
+		case !ok:
+			changes[name] = InputAdded
+		case !sameInput(baseInput, targetInput):
+			changes[name] = InputModified
+		}
+	}
+
+	for name := range baseInputs {
+		if _, ok := targetInputs[name]; !ok {
+			changes[name] = InputRemoved
+		}
+	}
+
+	return changes
+}
+
+// sameInput reports whether a and b would be dispatched identically;
+// descriptions and source positions are ignored.
+func sameInput(a, b Input) bool {
+	return a.InputType() == b.InputType() &&
+		a.Default == b.Default &&
+		a.Required == b.Required &&
+		slices.Equal(a.Options, b.Options)
+}
@@ -0,0 +1,56 @@
+package workflow_test
+
+import (
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+func dispatchFile(inputs map[string]workflow.Input) workflow.File {
+	return workflow.File{On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: inputs}}}
+}
+
+func TestDiffInputs(t *testing.T) {
+	t.Parallel()
+
+	local := dispatchFile(map[string]workflow.Input{
+		"env":     {Type: "choice", Options: []string{"dev", "prod"}},
+		"debug":   {Type: "boolean", Default: "false"},
+		"retired": {},
+		"note":    {Description: "old wording"},
+	})
+	remote := dispatchFile(map[string]workflow.Input{
+		"env":     {Type: "choice", Options: []string{"dev", "staging", "prod"}},
+		"debug":   {Type: "boolean", Default: "false"},
+		"note":    {Description: "new wording"},
+		"version": {Required: true},
+	})
+
+	got := workflow.DiffInputs(local, remote)
+
+	want := map[string]workflow.InputChange{
+		"env":     workflow.InputModified,
+		"retired": workflow.InputRemoved,
+		"version": workflow.InputAdded,
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("DiffInputs() = %v, want %v", got, want)
+	}
+
+	for name, change := range want {
+		if got[name] != change {
+			t.Errorf("DiffInputs()[%q] = %v, want %v", name, got[name], change)
+		}
+	}
+}
+
+func TestDiffInputs_Identical(t *testing.T) {
+	t.Parallel()
+
+	wf := dispatchFile(map[string]workflow.Input{"env": {Default: "dev"}})
+
+	if got := workflow.DiffInputs(wf, wf); len(got) != 0 {
+		t.Errorf("expected no changes for identical workflows, got %v", got)
+	}
+}
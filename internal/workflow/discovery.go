@@ -2,16 +2,174 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	pathpkg "path"
 	"path/filepath"
+	"runtime"
 	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
 )
 
+// DiscoveryReport summarizes a scan of the .github/workflows directory.
+// Files with malformed validation comments still appear in Dispatchable or
+// NonDispatchable; their comment problems are recorded in Failures alongside
+// files that could not be parsed at all.
+type DiscoveryReport struct {
+	Dispatchable    []File
+	NonDispatchable []File
+	Failures        []ParseError
+}
+
+// HasFailures returns true if any workflow file failed to parse.
+func (r *DiscoveryReport) HasFailures() bool {
+	return r != nil && len(r.Failures) > 0
+}
+
+// Source lists and reads workflow files from one version of a repository,
+// such as the working tree or a git ref.
+type Source interface {
+	// List returns the paths of workflow files, relative to the repository root.
+	List() ([]string, error)
+	// Read returns the contents of a path returned by List.
+	Read(path string) ([]byte, error)
+}
+
+// WorkflowDir is the repository-relative directory holding workflow files.
+const WorkflowDir = ".github/workflows"
+
+// IsWorkflowPath returns true if path names a YAML file directly inside WorkflowDir.
+func IsWorkflowPath(path string) bool {
+	dir, name := pathpkg.Split(filepath.ToSlash(path))
+	if strings.TrimSuffix(dir, "/") != WorkflowDir {
+		return false
+	}
+
+	ext := pathpkg.Ext(name)
+
+	return ext == ".yml" || ext == ".yaml"
+}
+
 // Discover finds all workflow files in the .github/workflows directory
-// and returns only those with workflow_dispatch triggers.
-func Discover(repoRoot string) ([]File, error) {
-	workflowDir := filepath.Join(repoRoot, ".github", "workflows")
+// and reports which are dispatchable, which are not, and which failed to parse.
+func Discover(repoRoot string) (*DiscoveryReport, error) {
+	return DiscoverCached(repoRoot, nil)
+}
+
+// DiscoverCached is like Discover but reuses the parse of every file whose
+// size and modification time match cache, then saves the cache. A nil cache
+// parses every file.
+func DiscoverCached(repoRoot string, cache *Cache) (*DiscoveryReport, error) {
+	report, err := discover(dirSource{root: repoRoot}, cache)
+	if err != nil || cache == nil {
+		return report, err
+	}
+
+	//nolint:errcheck,gosec // an unwritable cache only costs the next start a re-parse
+	cache.Save()
+
+	return report, nil
+}
+
+// DiscoverFrom is like Discover but reads workflow files from src.
+func DiscoverFrom(src Source) (*DiscoveryReport, error) {
+	return discover(src, nil)
+}
+
+func discover(src Source, cache *Cache) (*DiscoveryReport, error) {
+	files, err := src.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing workflow files: %w", err)
+	}
+
+	report := &DiscoveryReport{}
+
+	for i, result := range parseAll(src, files, cache) {
+		file := files[i]
+		if result.err != nil {
+			report.Failures = append(report.Failures, asParseError(file, result.err))
+			continue
+		}
+
+		wf := result.wf
+
+		for j := range wf.RuleErrors {
+			wf.RuleErrors[j].Path = file
+		}
+
+		report.Failures = append(report.Failures, wf.RuleErrors...)
+
+		var valueErrors []ParseError
+
+		wf, valueErrors = loadAllowedValues(wf, src)
+		for j := range valueErrors {
+			valueErrors[j].Path = file
+		}
+
+		report.Failures = append(report.Failures, valueErrors...)
+
+		if wf.IsDispatchable() {
+			report.Dispatchable = append(report.Dispatchable, wf)
+		} else {
+			report.NonDispatchable = append(report.NonDispatchable, wf)
+		}
+	}
+
+	resolveCalls(report)
+	sortFiles(report.Dispatchable)
+	sortFiles(report.NonDispatchable)
+	sort.SliceStable(report.Failures, func(i, j int) bool {
+		return report.Failures[i].Path < report.Failures[j].Path
+	})
+
+	return report, nil
+}
+
+// loadAllowedValues reads the values files of oneof-file rules on wf's inputs
+// through src, returning a problem per input whose file could not be read.
+// The inputs are copied first since wf may be shared with the parse cache,
+// which then never holds values that could go stale.
+func loadAllowedValues(wf File, src Source) (File, []ParseError) {
+	if wf.On.Dispatch == nil {
+		return wf, nil
+	}
+
+	var problems []ParseError
+
+	inputs := make(map[string]Input, len(wf.On.Dispatch.Inputs))
+
+	for name, input := range wf.On.Dispatch.Inputs {
+		rules, err := rule.LoadAllowedValues(input.ValidationRules, src.Read)
+		if err != nil {
+			problems = append(problems, ParseError{
+				Line:   input.Line,
+				Column: input.Column,
+				Err:    fmt.Errorf("input %q: %w", name, err),
+			})
+		}
+
+		input.ValidationRules = rules
+		inputs[name] = input
+	}
+
+	dispatch := *wf.On.Dispatch
+	dispatch.Inputs = inputs
+	wf.On.Dispatch = &dispatch
+
+	return wf, problems
+}
+
+// dirSource reads workflow files from the working tree rooted at root.
+type dirSource struct {
+	root string
+}
+
+func (s dirSource) List() ([]string, error) {
+	workflowDir := filepath.Join(s.root, filepath.FromSlash(WorkflowDir))
 
 	patterns := []string{
 		filepath.Join(workflowDir, "*.yml"),
@@ -26,31 +184,104 @@ func Discover(repoRoot string) ([]File, error) {
 			return nil, fmt.Errorf("globbing workflow files with pattern %q: %w", pattern, err)
 		}
 
-		files = append(files, matches...)
+		for _, match := range matches {
+			files = append(files, relativePath(s.root, match))
+		}
 	}
 
-	var workflows []File
+	return files, nil
+}
 
-	for _, file := range files {
-		wf, err := parseWorkflowFile(file)
-		if err != nil {
-			continue
-		}
+// Read reads a workflow file from List or a oneof-file rule's values file,
+// both repository-relative. Paths leaving root, even through a symlink, fail.
+func (s dirSource) Read(path string) ([]byte, error) {
+	root, err := os.OpenRoot(s.root)
+	if err != nil {
+		return nil, fmt.Errorf("opening repository root: %w", err)
+	}
+	defer root.Close() //nolint:errcheck // nothing was written through the read-only root
 
-		if wf.IsDispatchable() {
-			workflows = append(workflows, wf)
-		}
+	return root.ReadFile(filepath.FromSlash(path)) //nolint:wrapcheck // callers name the path
+}
+
+// stat describes path for the parse cache.
+func (s dirSource) stat(path string) (os.FileInfo, error) {
+	return os.Stat(filepath.Join(s.root, path)) //nolint:wrapcheck // only used to key the cache
+}
+
+// statSource is implemented by sources backed by the filesystem, whose
+// parsed files can be cached by size and modification time.
+type statSource interface {
+	stat(path string) (os.FileInfo, error)
+}
+
+// parseResult is the outcome of parsing one workflow file.
+type parseResult struct {
+	err error
+	wf  File
+}
+
+// parseAll parses files on a pool of at most GOMAXPROCS workers, returning
+// the results in the order of files.
+func parseAll(src Source, files []string, cache *Cache) []parseResult {
+	results := make([]parseResult, len(files))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(runtime.GOMAXPROCS(0), len(files)) {
+		wg.Go(func() {
+			for i := range indexes {
+				results[i].wf, results[i].err = parseCached(src, files[i], cache)
+			}
+		})
 	}
 
-	sort.Slice(workflows, func(i, j int) bool {
-		return workflows[i].Filename < workflows[j].Filename
-	})
+	for i := range files {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// parseCached parses path, going through cache when src is on the filesystem.
+// Files with validation comment errors are not cached, so their diagnostics
+// are always fresh.
+func parseCached(src Source, path string, cache *Cache) (File, error) {
+	fsSrc, ok := src.(statSource)
+	if cache == nil || !ok {
+		return parseWorkflowSource(src, path)
+	}
+
+	info, err := fsSrc.stat(path)
+	if err != nil {
+		return parseWorkflowSource(src, path)
+	}
+
+	key := filepath.ToSlash(path)
+	if wf, ok := cache.get(key, info); ok {
+		return wf, nil
+	}
+
+	wf, err := parseWorkflowSource(src, path)
+	if err == nil && len(wf.RuleErrors) == 0 {
+		cache.put(key, info, wf)
+	}
 
-	return workflows, nil
+	return wf, err
 }
 
-func parseWorkflowFile(path string) (File, error) {
-	data, err := os.ReadFile(path) //nolint:gosec // path from walking repo's .github/workflows dir
+func sortFiles(files []File) {
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Filename < files[j].Filename
+	})
+}
+
+func parseWorkflowSource(src Source, path string) (File, error) {
+	data, err := src.Read(path)
 	if err != nil {
 		return File{}, fmt.Errorf("reading workflow file %s: %w", path, err)
 	}
@@ -60,7 +291,31 @@ func parseWorkflowFile(path string) (File, error) {
 		return File{}, err
 	}
 
-	wf.Filename = filepath.Base(path)
+	wf.Filename = pathpkg.Base(filepath.ToSlash(path))
 
 	return wf, nil
 }
+
+// relativePath returns path relative to repoRoot for display, falling back to path itself.
+func relativePath(repoRoot, path string) string {
+	rel, err := filepath.Rel(repoRoot, path)
+	if err != nil {
+		return path
+	}
+
+	return rel
+}
+
+// asParseError converts err into a ParseError located in path, keeping any
+// line and column information Parse already attached.
+func asParseError(path string, err error) ParseError {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		located := *parseErr
+		located.Path = path
+
+		return located
+	}
+
+	return ParseError{Path: path, Err: err}
+}
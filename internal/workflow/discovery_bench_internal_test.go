@@ -0,0 +1,124 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// benchWorkflowCount approximates a large monorepo's workflow directory.
+const benchWorkflowCount = 150
+
+// benchWorkflow is a realistic dispatchable workflow with commented inputs and several jobs.
+const benchWorkflow = `name: Deploy %[1]d
+on:
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target environment
+        type: choice
+        options: [staging, production]
+        default: staging
+      version:
+        # lazydispatch:validate:regex:^v\d+\.\d+\.\d+$
+        description: Version to deploy
+        required: true
+      replicas:
+        # lazydispatch:validate:range:1-10
+        type: number
+        default: "2"
+      dry_run:
+        type: boolean
+        default: "false"
+concurrency:
+  group: deploy-%[1]d-${{ inputs.environment }}
+  cancel-in-progress: true
+permissions:
+  contents: read
+  deployments: write
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make build
+  deploy:
+    needs: build
+    if: ${{ !inputs.dry_run }}
+    environment: ${{ inputs.environment }}
+    runs-on: [self-hosted, linux]
+    steps:
+      - run: ./deploy.sh
+  notify:
+    needs: [build, deploy]
+    if: always()
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo done
+`
+
+func writeBenchWorkflows(b *testing.B) string {
+	b.Helper()
+
+	root := b.TempDir()
+
+	dir := filepath.Join(root, filepath.FromSlash(WorkflowDir))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		b.Fatal(err)
+	}
+
+	for i := range benchWorkflowCount {
+		name := filepath.Join(dir, fmt.Sprintf("deploy-%03d.yml", i))
+		if err := os.WriteFile(name, fmt.Appendf(nil, benchWorkflow, i), 0o600); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func BenchmarkParse(b *testing.B) {
+	data := fmt.Appendf(nil, benchWorkflow, 1)
+
+	b.ReportAllocs()
+
+	for range b.N {
+		if _, err := Parse(data); err != nil {
+			b.Fatalf("Parse failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkDiscover_Cold(b *testing.B) {
+	root := writeBenchWorkflows(b)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for range b.N {
+		report, err := Discover(root)
+		if err != nil || len(report.Dispatchable) != benchWorkflowCount {
+			b.Fatalf("Discover failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkDiscover_WarmCache(b *testing.B) {
+	root := writeBenchWorkflows(b)
+	cacheDir := b.TempDir()
+
+	if _, err := DiscoverCached(root, NewCache(cacheDir, root)); err != nil {
+		b.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for range b.N {
+		report, err := DiscoverCached(root, NewCache(cacheDir, root))
+		if err != nil || len(report.Dispatchable) != benchWorkflowCount {
+			b.Fatalf("DiscoverCached failed: %v", err)
+		}
+	}
+}
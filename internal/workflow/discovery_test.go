@@ -1,9 +1,11 @@
 package workflow_test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/kyleking/gh-lazydispatch/internal/workflow"
@@ -18,11 +20,13 @@ func TestDiscover(t *testing.T) {
 	}
 	repoRoot := filepath.Join(filepath.Dir(currentFile), "..", "..", "testdata")
 
-	workflows, err := workflow.Discover(repoRoot)
+	report, err := workflow.Discover(repoRoot)
 	if err != nil {
 		t.Fatalf("Discover failed: %v", err)
 	}
 
+	workflows := report.Dispatchable
+
 	if len(workflows) != 12 {
 		t.Errorf("expected 12 dispatchable workflows, got %d", len(workflows))
 
@@ -43,18 +47,26 @@ func TestDiscover(t *testing.T) {
 	if filenames["not-dispatchable.yml"] {
 		t.Error("not-dispatchable.yml should not be included")
 	}
+
+	if len(report.NonDispatchable) != 2 {
+		t.Errorf("expected 2 non-dispatchable workflows, got %d", len(report.NonDispatchable))
+	}
+
+	if report.HasFailures() {
+		t.Errorf("expected no failures, got %v", report.Failures)
+	}
 }
 
 func TestDiscover_NonExistentDir(t *testing.T) {
 	t.Parallel()
 
-	workflows, err := workflow.Discover("/nonexistent/path")
+	report, err := workflow.Discover("/nonexistent/path")
 	if err != nil {
 		t.Fatalf("Discover should not error on missing dir: %v", err)
 	}
 
-	if len(workflows) != 0 {
-		t.Errorf("expected 0 workflows for missing dir, got %d", len(workflows))
+	if len(report.Dispatchable) != 0 {
+		t.Errorf("expected 0 workflows for missing dir, got %d", len(report.Dispatchable))
 	}
 }
 
@@ -67,12 +79,312 @@ func TestDiscover_EmptyDir(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	workflows, err := workflow.Discover(tmpDir)
+	report, err := workflow.Discover(tmpDir)
 	if err != nil {
 		t.Fatalf("Discover failed: %v", err)
 	}
 
-	if len(workflows) != 0 {
-		t.Errorf("expected 0 workflows for empty dir, got %d", len(workflows))
+	if len(report.Dispatchable) != 0 {
+		t.Errorf("expected 0 workflows for empty dir, got %d", len(report.Dispatchable))
+	}
+}
+
+// writeWorkflow writes content to .github/workflows/name under root.
+func writeWorkflow(t *testing.T, root, name, content string) {
+	t.Helper()
+
+	dir := filepath.Join(root, ".github", "workflows")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatal(err)
 	}
 }
+
+func TestDiscover_ReportsParseFailures(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	writeWorkflow(t, tmpDir, "good.yml", "name: Good\non: workflow_dispatch\n")
+	writeWorkflow(t, tmpDir, "broken.yml", "name: Broken\non:\n  workflow_dispatch:\n    inputs: [\n")
+
+	report, err := workflow.Discover(tmpDir)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(report.Dispatchable) != 1 || report.Dispatchable[0].Filename != "good.yml" {
+		t.Errorf("expected only good.yml to be dispatchable, got %v", report.Dispatchable)
+	}
+
+	if len(report.Failures) != 1 {
+		t.Fatalf("expected 1 failure, got %d: %v", len(report.Failures), report.Failures)
+	}
+
+	failure := report.Failures[0]
+	if failure.Path != filepath.Join(".github", "workflows", "broken.yml") {
+		t.Errorf("unexpected failure path %q", failure.Path)
+	}
+
+	if failure.Line == 0 {
+		t.Errorf("expected a line number, got %+v", failure)
+	}
+}
+
+func TestDiscover_ReportsRuleCommentErrors(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	writeWorkflow(t, tmpDir, "deploy.yml", `name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      replicas:
+        # lazydispatch:validate:range:10-1
+        type: string
+`)
+
+	report, err := workflow.Discover(tmpDir)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if len(report.Dispatchable) != 1 {
+		t.Fatalf("expected workflow with bad comment to stay dispatchable, got %d", len(report.Dispatchable))
+	}
+
+	if len(report.Failures) != 1 {
+		t.Fatalf("expected 1 failure, got %d", len(report.Failures))
+	}
+
+	failure := report.Failures[0]
+	if failure.Line != 5 || failure.Column != 7 {
+		t.Errorf("expected failure at 5:7, got %d:%d", failure.Line, failure.Column)
+	}
+}
+
+func TestDiscoverCached_LoadsAllowedValues(t *testing.T) {
+	t.Parallel()
+
+	root, cacheDir := t.TempDir(), t.TempDir()
+
+	writeWorkflow(t, root, "deploy.yml", `name: Deploy
+on:
+  workflow_dispatch:
+    inputs:
+      region:
+        # lazydispatch:validate:oneof-file:deploy/regions.txt
+        type: string
+`)
+
+	report, err := workflow.DiscoverCached(root, workflow.NewCache(cacheDir, root))
+	if err != nil {
+		t.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	if len(report.Failures) != 1 || report.Failures[0].Line != 5 {
+		t.Fatalf("expected the missing values file reported at the input, got %v", report.Failures)
+	}
+
+	valuesPath := filepath.Join(root, "deploy", "regions.txt")
+	if err := os.MkdirAll(filepath.Dir(valuesPath), 0o750); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+
+	if err := os.WriteFile(valuesPath, []byte("us-east-1\neu-west-1\n"), 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	// The workflow comes from the cache, but its values file is read again.
+	report, err = workflow.DiscoverCached(root, workflow.NewCache(cacheDir, root))
+	if err != nil {
+		t.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	if report.HasFailures() {
+		t.Fatalf("unexpected failures: %v", report.Failures)
+	}
+
+	rules := report.Dispatchable[0].GetInputs()["region"].ValidationRules
+	if len(rules) != 1 || strings.Join(rules[0].Values, ",") != "us-east-1,eu-west-1" {
+		t.Errorf("expected the allowed values to be loaded, got %+v", rules)
+	}
+}
+
+// mapSource is an in-memory workflow.Source keyed by repository-relative path.
+type mapSource map[string]string
+
+func (s mapSource) List() ([]string, error) {
+	paths := make([]string, 0, len(s))
+	for path := range s {
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+func (s mapSource) Read(path string) ([]byte, error) {
+	return []byte(s[path]), nil
+}
+
+func TestDiscoverFrom(t *testing.T) {
+	t.Parallel()
+
+	report, err := workflow.DiscoverFrom(mapSource{
+		".github/workflows/b.yml": "name: B\non: workflow_dispatch\n",
+		".github/workflows/a.yml": "name: A\non: workflow_dispatch\n",
+		".github/workflows/c.yml": "name: C\non: push\n",
+	})
+	if err != nil {
+		t.Fatalf("DiscoverFrom failed: %v", err)
+	}
+
+	if len(report.Dispatchable) != 2 || report.Dispatchable[0].Filename != "a.yml" {
+		t.Errorf("expected a.yml and b.yml in order, got %v", report.Dispatchable)
+	}
+
+	if len(report.NonDispatchable) != 1 {
+		t.Errorf("expected 1 non-dispatchable workflow, got %d", len(report.NonDispatchable))
+	}
+}
+
+func TestIsWorkflowPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{".github/workflows/ci.yml", true},
+		{".github/workflows/ci.yaml", true},
+		{".github/workflows/README.md", false},
+		{".github/workflows/nested/ci.yml", false},
+		{"ci.yml", false},
+	}
+
+	for _, tt := range tests {
+		if got := workflow.IsWorkflowPath(tt.path); got != tt.want {
+			t.Errorf("IsWorkflowPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverCached_ReusesUnchangedFiles(t *testing.T) {
+	t.Parallel()
+
+	root, cacheDir := t.TempDir(), t.TempDir()
+	writeWorkflow(t, root, "deploy.yml", "name: Deploy\non: workflow_dispatch\n")
+	writeWorkflow(t, root, "build.yml", "name: Build\non: workflow_dispatch\n")
+
+	path := filepath.Join(root, ".github", "workflows", "deploy.yml")
+
+	if _, err := workflow.DiscoverCached(root, workflow.NewCache(cacheDir, root)); err != nil {
+		t.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat failed: %v", err)
+	}
+
+	// Same size and modification time: a warm start must not re-parse it.
+	if err := os.WriteFile(path, []byte("name: Deplyo\non: workflow_dispatch\n"), 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("chtimes failed: %v", err)
+	}
+
+	report, err := workflow.DiscoverCached(root, workflow.NewCache(cacheDir, root))
+	if err != nil {
+		t.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	if got := dispatchableNames(report); got != "Build,Deploy" {
+		t.Errorf("expected cached names, got %s", got)
+	}
+
+	// A changed size invalidates the entry.
+	writeWorkflow(t, root, "deploy.yml", "name: Deploy app\non: workflow_dispatch\n")
+
+	report, err = workflow.DiscoverCached(root, workflow.NewCache(cacheDir, root))
+	if err != nil {
+		t.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	if got := dispatchableNames(report); got != "Build,Deploy app" {
+		t.Errorf("expected re-parsed names, got %s", got)
+	}
+}
+
+func TestDiscoverCached_IgnoresCorruptCache(t *testing.T) {
+	t.Parallel()
+
+	root, cacheDir := t.TempDir(), t.TempDir()
+	writeWorkflow(t, root, "deploy.yml", "name: Deploy\non: workflow_dispatch\n")
+
+	if _, err := workflow.DiscoverCached(root, workflow.NewCache(cacheDir, root)); err != nil {
+		t.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(cacheDir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one cache file, got %v (%v)", entries, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(cacheDir, entries[0].Name()), []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	report, err := workflow.DiscoverCached(root, workflow.NewCache(cacheDir, root))
+	if err != nil {
+		t.Fatalf("DiscoverCached failed: %v", err)
+	}
+
+	if got := dispatchableNames(report); got != "Deploy" {
+		t.Errorf("expected Deploy, got %s", got)
+	}
+}
+
+func TestDiscover_ManyFilesKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	var want []string
+
+	for i := range 40 {
+		name := fmt.Sprintf("wf-%02d", i)
+		writeWorkflow(t, root, name+".yml", "name: "+name+"\non: workflow_dispatch\n")
+		want = append(want, name)
+	}
+
+	writeWorkflow(t, root, "broken.yml", "on: [\n")
+
+	report, err := workflow.Discover(root)
+	if err != nil {
+		t.Fatalf("Discover failed: %v", err)
+	}
+
+	if got := dispatchableNames(report); got != strings.Join(want, ",") {
+		t.Errorf("unexpected order: %s", got)
+	}
+
+	if len(report.Failures) != 1 || report.Failures[0].Path != filepath.Join(".github", "workflows", "broken.yml") {
+		t.Errorf("expected one failure for broken.yml, got %v", report.Failures)
+	}
+}
+
+func dispatchableNames(report *workflow.DiscoveryReport) string {
+	names := make([]string, len(report.Dispatchable))
+	for i, wf := range report.Dispatchable {
+		names[i] = wf.Name
+	}
+
+	return strings.Join(names, ",")
+}
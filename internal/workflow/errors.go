@@ -0,0 +1,57 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// ParseError describes a problem found at a specific location in a workflow file.
+// Line and Column are 1-based; zero means the location is unknown (yaml syntax
+// errors only report a line).
+type ParseError struct {
+	Err    error
+	Path   string
+	Line   int
+	Column int
+}
+
+func (e *ParseError) Error() string {
+	return e.Location() + ": " + e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Location formats the error position as "path:line:column", omitting unknown parts.
+func (e *ParseError) Location() string {
+	loc := e.Path
+	if loc == "" {
+		loc = "<input>"
+	}
+
+	if e.Line > 0 {
+		loc += ":" + strconv.Itoa(e.Line)
+
+		if e.Column > 0 {
+			loc += ":" + strconv.Itoa(e.Column)
+		}
+	}
+
+	return loc
+}
+
+// yamlLinePattern matches the "line N" position yaml.v3 embeds in its error messages.
+var yamlLinePattern = regexp.MustCompile(`line (\d+)`)
+
+// newYAMLParseError wraps a yaml.v3 error, recovering the line number from its message.
+func newYAMLParseError(err error) *ParseError {
+	parseErr := &ParseError{Err: fmt.Errorf("parsing workflow YAML: %w", err)}
+
+	if match := yamlLinePattern.FindStringSubmatch(err.Error()); match != nil {
+		parseErr.Line, _ = strconv.Atoi(match[1]) //nolint:errcheck // regexp guarantees digits
+	}
+
+	return parseErr
+}
@@ -0,0 +1,221 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Job represents a single job in a workflow's "jobs" map.
+type Job struct {
+	Concurrency *Concurrency      `yaml:"concurrency"`
+	Permissions *Permissions      `yaml:"permissions"`
+	Environment JobEnvironment    `yaml:"environment"`
+	ID          string            `yaml:"-"`
+	With        map[string]string `yaml:"with"`
+	Name        string            `yaml:"name"`
+	If          string            `yaml:"if"`
+	Uses        string            `yaml:"uses"`
+	Needs       StringList        `yaml:"needs"`
+	RunsOn      StringList        `yaml:"runs-on"`
+}
+
+// DisplayName returns the job's name, falling back to its ID.
+func (j Job) DisplayName() string {
+	if j.Name != "" {
+		return j.Name
+	}
+
+	return j.ID
+}
+
+// Jobs is the list of a workflow's jobs in declaration order.
+type Jobs []Job
+
+// UnmarshalYAML decodes the "jobs" mapping, keeping declaration order and
+// recording each job's ID. Jobs that are not mappings are skipped.
+func (j *Jobs) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i+1].Kind != yaml.MappingNode {
+			continue
+		}
+
+		var job Job
+		if err := node.Content[i+1].Decode(&job); err != nil {
+			return &ParseError{
+				Err:    fmt.Errorf("decoding job %q: %w", node.Content[i].Value, err),
+				Line:   node.Content[i].Line,
+				Column: node.Content[i].Column,
+			}
+		}
+
+		job.ID = node.Content[i].Value
+		*j = append(*j, job)
+	}
+
+	return nil
+}
+
+// Environments returns the distinct environment names the jobs deploy to, sorted.
+func (j Jobs) Environments() []string {
+	seen := make(map[string]bool)
+
+	var envs []string
+
+	for _, job := range j {
+		if name := job.Environment.Name; name != "" && !seen[name] {
+			seen[name] = true
+
+			envs = append(envs, name)
+		}
+	}
+
+	sort.Strings(envs)
+
+	return envs
+}
+
+// StringList is a YAML value written either as a single string or as a list,
+// such as "needs" and "runs-on".
+type StringList []string
+
+// UnmarshalYAML accepts a scalar, a sequence, or a runs-on style mapping with
+// "group" and "labels" keys.
+func (s *StringList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*s = StringList{node.Value}
+	case yaml.SequenceNode:
+		var values []string
+		if err := node.Decode(&values); err != nil {
+			return fmt.Errorf("decoding list: %w", err)
+		}
+
+		*s = values
+	case yaml.MappingNode:
+		var runner struct {
+			Group  string     `yaml:"group"`
+			Labels StringList `yaml:"labels"`
+		}
+
+		if err := node.Decode(&runner); err != nil {
+			return fmt.Errorf("decoding runner group: %w", err)
+		}
+
+		if runner.Group != "" {
+			*s = append(*s, runner.Group)
+		}
+
+		*s = append(*s, runner.Labels...)
+	case yaml.DocumentNode, yaml.AliasNode:
+	}
+
+	return nil
+}
+
+// JobEnvironment is a job's deployment environment, written either as a name
+// or as a mapping with "name" and "url".
+type JobEnvironment struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+// UnmarshalYAML accepts both the short and the mapping form.
+func (e *JobEnvironment) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		e.Name = node.Value
+		return nil
+	}
+
+	type plain JobEnvironment
+
+	if err := node.Decode((*plain)(e)); err != nil {
+		return fmt.Errorf("decoding environment: %w", err)
+	}
+
+	return nil
+}
+
+// Concurrency is a workflow or job concurrency setting.
+type Concurrency struct {
+	Group string `yaml:"group"`
+	// CancelInProgress is "true", "false", empty, or an expression evaluated at run time.
+	CancelInProgress string `yaml:"cancel-in-progress"`
+}
+
+// UnmarshalYAML accepts both a bare group name and the mapping form.
+func (c *Concurrency) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		c.Group = node.Value
+		return nil
+	}
+
+	type plain Concurrency
+
+	if err := node.Decode((*plain)(c)); err != nil {
+		return fmt.Errorf("decoding concurrency: %w", err)
+	}
+
+	return nil
+}
+
+// CancelsInProgress returns true if a new run always cancels in-progress runs in the group.
+func (c *Concurrency) CancelsInProgress() bool {
+	return c != nil && c.CancelInProgress == "true"
+}
+
+// MayCancelInProgress returns true if cancellation depends on an expression.
+func (c *Concurrency) MayCancelInProgress() bool {
+	return c != nil && strings.Contains(c.CancelInProgress, "${{")
+}
+
+// Permissions is a GITHUB_TOKEN permissions block, written either as
+// "read-all", "write-all", "{}" or as a mapping of scope to access level.
+type Permissions struct {
+	Scopes map[string]string
+	All    string
+}
+
+// UnmarshalYAML accepts both the shorthand and the mapping form.
+func (p *Permissions) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		p.All = node.Value
+		return nil
+	}
+
+	if err := node.Decode(&p.Scopes); err != nil {
+		return fmt.Errorf("decoding permissions: %w", err)
+	}
+
+	return nil
+}
+
+// String summarizes the permissions, e.g. "read-all" or "contents: read, deployments: write".
+func (p *Permissions) String() string {
+	if p == nil {
+		return ""
+	}
+
+	if p.All != "" {
+		return p.All
+	}
+
+	if len(p.Scopes) == 0 {
+		return "none"
+	}
+
+	scopes := make([]string, 0, len(p.Scopes))
+	for scope, level := range p.Scopes {
+		scopes = append(scopes, scope+": "+level)
+	}
+
+	sort.Strings(scopes)
+
+	return strings.Join(scopes, ", ")
+}
@@ -0,0 +1,103 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	pathpkg "path"
+	"strings"
+)
+
+// Options provider kinds, as written in "# lazydispatch:options:<kind>" comments.
+const (
+	OptionsGitTags     = "git-tags"
+	OptionsGitBranches = "git-branches"
+	OptionsReleases    = "releases"
+	OptionsCommand     = "cmd"
+)
+
+const optionsPrefix = "lazydispatch:options:"
+
+// Errors returned while parsing options comments.
+var (
+	ErrUnknownOptionsProvider   = errors.New("unknown options provider (expected git-tags, git-branches, releases or cmd)")
+	ErrOptionsCommandMissing    = errors.New("cmd options provider requires a command")
+	ErrMultipleOptionsProviders = errors.New("more than one options comment")
+)
+
+// OptionsProvider names where a free-text input's options are listed from,
+// set by a "# lazydispatch:options:<kind>[:<arg>]" comment. The options are
+// fetched when the input is edited, not at parse time.
+type OptionsProvider struct {
+	Kind string
+	// Arg is a glob filtering git-tags, git-branches and releases, such as
+	// "v*", or the command line for cmd.
+	Arg string
+}
+
+// String returns the provider as written in its comment, without the prefix.
+func (p OptionsProvider) String() string {
+	if p.Arg == "" {
+		return p.Kind
+	}
+
+	return p.Kind + ":" + p.Arg
+}
+
+// Describe returns a short human-readable description of the provider.
+func (p OptionsProvider) Describe() string {
+	var source string
+
+	switch p.Kind {
+	case OptionsGitTags:
+		source = "git tags"
+	case OptionsGitBranches:
+		source = "branches"
+	case OptionsReleases:
+		source = "releases"
+	case OptionsCommand:
+		return "output of " + p.Arg
+	}
+
+	if p.Arg != "" {
+		source += " matching " + p.Arg
+	}
+
+	return source
+}
+
+// parseOptionsComments returns the options provider declared in an input's
+// comment lines, or nil if there is none.
+func parseOptionsComments(lines []string) (*OptionsProvider, error) {
+	var provider *OptionsProvider
+
+	for _, line := range lines {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "#"))
+		if !strings.HasPrefix(line, optionsPrefix) {
+			continue
+		}
+
+		if provider != nil {
+			return nil, ErrMultipleOptionsProviders
+		}
+
+		kind, arg, _ := strings.Cut(strings.TrimPrefix(line, optionsPrefix), ":")
+		p := OptionsProvider{Kind: strings.TrimSpace(kind), Arg: strings.TrimSpace(arg)}
+
+		switch p.Kind {
+		case OptionsGitTags, OptionsGitBranches, OptionsReleases:
+			if _, err := pathpkg.Match(p.Arg, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", p.Arg, err)
+			}
+		case OptionsCommand:
+			if p.Arg == "" {
+				return nil, ErrOptionsCommandMissing
+			}
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrUnknownOptionsProvider, p.Kind)
+		}
+
+		provider = &p
+	}
+
+	return provider, nil
+}
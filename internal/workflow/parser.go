@@ -1,6 +1,7 @@
 package workflow
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -12,37 +13,84 @@ import (
 // workflowDispatchTrigger is the GitHub Actions trigger name that makes a workflow dispatchable.
 const workflowDispatchTrigger = "workflow_dispatch"
 
+// workflowCallTrigger is the GitHub Actions trigger name that makes a workflow reusable.
+const workflowCallTrigger = "workflow_call"
+
 // Parse parses workflow YAML content into a File struct.
+// YAML errors are returned as a *ParseError carrying the offending line.
+// Malformed validation comments do not fail the parse; they are collected in
+// File.RuleErrors so the rest of the workflow remains usable.
+// The YAML is parsed into a node tree once, which serves both decoding and
+// reading the validation comments.
 func Parse(data []byte) (File, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return File{}, newYAMLParseError(err)
+	}
+
+	// An empty document decodes to the zero workflow.
+	if root.Kind == 0 {
+		return File{}, nil
+	}
+
 	var raw rawWorkflow
-	if err := yaml.Unmarshal(data, &raw); err != nil {
-		return File{}, fmt.Errorf("parsing workflow YAML: %w", err)
+	if err := root.Decode(&raw); err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			return File{}, parseErr
+		}
+
+		return File{}, newYAMLParseError(err)
 	}
 
 	wf := File{
-		Name: raw.Name,
+		Name:        raw.Name,
+		Concurrency: raw.Concurrency,
+		Permissions: raw.Permissions,
+		Jobs:        raw.Jobs,
 	}
 
 	if raw.On.Dispatch != nil {
 		wf.On.Dispatch = raw.On.Dispatch
 	}
 
-	inputComments, err := parseInputComments(data)
-	if err != nil {
-		return wf, err
-	}
+	wf.On.Call = raw.On.Call
+
+	inputComments := parseInputComments(&root)
 
 	if wf.On.Dispatch != nil && wf.On.Dispatch.Inputs != nil {
 		for name, input := range wf.On.Dispatch.Inputs {
-			if comments, ok := inputComments[name]; ok {
-				rules, err := rule.ParseValidationComments(comments)
-				if err != nil {
-					continue
-				}
+			comments, ok := inputComments[name]
+			if !ok {
+				continue
+			}
 
+			input.Line = comments.Line
+			input.Column = comments.Column
+
+			rules, err := rule.ParseValidationComments(comments.Lines)
+			if err != nil {
+				wf.RuleErrors = append(wf.RuleErrors, ParseError{
+					Err:    fmt.Errorf("input %q: invalid validation comment: %w", name, err),
+					Line:   comments.Line,
+					Column: comments.Column,
+				})
+			} else {
 				input.ValidationRules = rules
-				wf.On.Dispatch.Inputs[name] = input
 			}
+
+			provider, err := parseOptionsComments(comments.Lines)
+			if err != nil {
+				wf.RuleErrors = append(wf.RuleErrors, ParseError{
+					Err:    fmt.Errorf("input %q: invalid options comment: %w", name, err),
+					Line:   comments.Line,
+					Column: comments.Column,
+				})
+			} else {
+				input.OptionsProvider = provider
+			}
+
+			wf.On.Dispatch.Inputs[name] = input
 		}
 	}
 
@@ -51,42 +99,58 @@ func Parse(data []byte) (File, error) {
 
 // rawWorkflow handles the flexible "on" field parsing.
 type rawWorkflow struct {
-	On   rawOnTrigger `yaml:"on"`
-	Name string       `yaml:"name"`
+	On          rawOnTrigger `yaml:"on"`
+	Concurrency *Concurrency `yaml:"concurrency"`
+	Permissions *Permissions `yaml:"permissions"`
+	Name        string       `yaml:"name"`
+	Jobs        Jobs         `yaml:"jobs"`
 }
 
 // rawOnTrigger handles "on" being either a string, list, or map.
 type rawOnTrigger struct {
 	Dispatch *Dispatch
+	Call     *Dispatch
 }
 
 func (t *rawOnTrigger) UnmarshalYAML(node *yaml.Node) error {
 	switch node.Kind {
 	case yaml.ScalarNode:
-		if node.Value == workflowDispatchTrigger {
-			t.Dispatch = &Dispatch{}
-		}
+		t.setTrigger(node.Value)
 	case yaml.SequenceNode:
 		var triggers []string
 		if err := node.Decode(&triggers); err == nil {
 			for _, trigger := range triggers {
-				if trigger == workflowDispatchTrigger {
-					t.Dispatch = &Dispatch{}
-					break
-				}
+				t.setTrigger(trigger)
 			}
 		}
 	case yaml.MappingNode:
 		var m struct {
 			//nolint:tagliatelle // matches GitHub Actions workflow YAML schema
 			Dispatch *Dispatch `yaml:"workflow_dispatch"`
+			//nolint:tagliatelle // matches GitHub Actions workflow YAML schema
+			Call *Dispatch `yaml:"workflow_call"`
 		}
 
 		if err := node.Decode(&m); err != nil {
-			return fmt.Errorf("decoding workflow \"on\" trigger: %w", err)
+			return &ParseError{
+				Err:    fmt.Errorf("decoding workflow \"on\" trigger: %w", err),
+				Line:   node.Line,
+				Column: node.Column,
+			}
 		}
 
 		t.Dispatch = m.Dispatch
+		t.Call = m.Call
+
+		// A bare "workflow_dispatch:" or "workflow_call:" key decodes to nil
+		// but still enables the trigger, as it does on GitHub.
+		if t.Dispatch == nil && hasKey(node, workflowDispatchTrigger) {
+			t.Dispatch = &Dispatch{}
+		}
+
+		if t.Call == nil && hasKey(node, workflowCallTrigger) {
+			t.Call = &Dispatch{}
+		}
 	case yaml.DocumentNode, yaml.AliasNode:
 		// not valid shapes for the "on" field; nothing to decode
 	}
@@ -94,32 +158,58 @@ func (t *rawOnTrigger) UnmarshalYAML(node *yaml.Node) error {
 	return nil
 }
 
-// parseInputComments extracts comments from workflow input definitions.
-// Returns a map of input name to associated comments.
-func parseInputComments(data []byte) (map[string][]string, error) {
-	var root yaml.Node
-	if err := yaml.Unmarshal(data, &root); err != nil {
-		return nil, fmt.Errorf("parsing workflow YAML for comments: %w", err)
+// setTrigger records a trigger named in the scalar or list form of "on".
+func (t *rawOnTrigger) setTrigger(trigger string) {
+	switch trigger {
+	case workflowDispatchTrigger:
+		t.Dispatch = &Dispatch{}
+	case workflowCallTrigger:
+		t.Call = &Dispatch{}
 	}
+}
 
-	result := make(map[string][]string)
+// hasKey returns true if the mapping node has the given key.
+func hasKey(node *yaml.Node, key string) bool {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inputComments holds the comment lines attached to one input and the
+// position of the input's key, used to locate the input in diagnostics.
+type inputComments struct {
+	Lines  []string
+	Line   int
+	Column int
+}
+
+// parseInputComments extracts comments from workflow input definitions.
+// Returns a map of input name to associated comments; every input has an
+// entry so its position is known even when it has no comments.
+func parseInputComments(root *yaml.Node) map[string]inputComments {
+	result := make(map[string]inputComments)
 
-	inputsNode := findInputsNode(&root)
+	inputsNode := findInputsNode(root)
 	if inputsNode == nil {
-		return result, nil
+		return result
 	}
 
 	for i := 0; i < len(inputsNode.Content)-1; i += 2 {
 		keyNode := inputsNode.Content[i]
 		valueNode := inputsNode.Content[i+1]
 
-		comments := commentsForInput(keyNode, valueNode)
-		if len(comments) > 0 {
-			result[keyNode.Value] = comments
+		result[keyNode.Value] = inputComments{
+			Lines:  commentsForInput(keyNode, valueNode),
+			Line:   keyNode.Line,
+			Column: keyNode.Column,
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 // commentsForInput collects all comments attached to a single workflow input:
@@ -1,6 +1,9 @@
 package workflow_test
 
 import (
+	"errors"
+	"path"
+	"slices"
 	"testing"
 
 	"github.com/kyleking/gh-lazydispatch/internal/workflow"
@@ -172,3 +175,329 @@ func TestWorkflowInput_InputType_Explicit(t *testing.T) {
 		t.Errorf("expected type 'boolean', got %q", input.InputType())
 	}
 }
+
+func TestParse_InvalidYAMLReportsLine(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("name: Broken\non:\n  workflow_dispatch:\n\tinputs: {}\n")
+
+	_, err := workflow.Parse(data)
+	if err == nil {
+		t.Fatal("expected error for invalid YAML")
+	}
+
+	var parseErr *workflow.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *workflow.ParseError, got %T", err)
+	}
+
+	if parseErr.Line != 4 {
+		t.Errorf("expected line 4, got %d", parseErr.Line)
+	}
+}
+
+func TestParse_InvalidValidationCommentIsRecorded(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`
+on:
+  workflow_dispatch:
+    inputs:
+      version:
+        # lazydispatch:validate:regex:
+        type: string
+      tag:
+        # lazydispatch:validate:prefix:v
+        type: string
+`)
+
+	wf, err := workflow.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(wf.RuleErrors) != 1 {
+		t.Fatalf("expected 1 rule error, got %d", len(wf.RuleErrors))
+	}
+
+	if wf.RuleErrors[0].Line != 5 {
+		t.Errorf("expected rule error on line 5, got %d", wf.RuleErrors[0].Line)
+	}
+
+	if len(wf.GetInputs()["tag"].ValidationRules) != 1 {
+		t.Error("expected valid rules on other inputs to still be applied")
+	}
+}
+
+func TestParse_SkipsNonMappingJobs(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`
+on: workflow_dispatch
+jobs:
+  a: foo
+  b: [x, y]
+  c:
+  build:
+    runs-on: ubuntu-latest
+`)
+
+	wf, err := workflow.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(wf.Jobs) != 1 || wf.Jobs[0].ID != "build" {
+		t.Errorf("expected only the build job, got %+v", wf.Jobs)
+	}
+}
+
+func TestParse_JobsConcurrencyAndPermissions(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`
+name: Deploy
+on: workflow_dispatch
+concurrency:
+  group: deploy-${{ github.ref }}
+  cancel-in-progress: true
+permissions:
+  contents: read
+  deployments: write
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  deploy:
+    name: Deploy app
+    needs: build
+    environment: production
+    runs-on: [self-hosted, linux]
+  notify:
+    needs: [build, deploy]
+    environment:
+      name: staging
+      url: https://staging.example.com
+    runs-on:
+      group: larger-runners
+      labels: gpu
+    concurrency: notify
+    permissions: read-all
+`)
+
+	wf, err := workflow.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	ids := make([]string, len(wf.Jobs))
+	for i, job := range wf.Jobs {
+		ids[i] = job.ID
+	}
+
+	if !slices.Equal(ids, []string{"build", "deploy", "notify"}) {
+		t.Errorf("expected jobs in declaration order, got %v", ids)
+	}
+
+	deploy, notify := wf.Jobs[1], wf.Jobs[2]
+
+	if deploy.DisplayName() != "Deploy app" || wf.Jobs[0].DisplayName() != "build" {
+		t.Errorf("unexpected display names %q, %q", deploy.DisplayName(), wf.Jobs[0].DisplayName())
+	}
+
+	if !slices.Equal(deploy.Needs, workflow.StringList{"build"}) {
+		t.Errorf("expected scalar needs to parse, got %v", deploy.Needs)
+	}
+
+	if !slices.Equal(notify.Needs, workflow.StringList{"build", "deploy"}) {
+		t.Errorf("expected list needs to parse, got %v", notify.Needs)
+	}
+
+	if !slices.Equal(deploy.RunsOn, workflow.StringList{"self-hosted", "linux"}) {
+		t.Errorf("unexpected runs-on %v", deploy.RunsOn)
+	}
+
+	if !slices.Equal(notify.RunsOn, workflow.StringList{"larger-runners", "gpu"}) {
+		t.Errorf("unexpected runs-on group %v", notify.RunsOn)
+	}
+
+	if notify.Environment.Name != "staging" || notify.Environment.URL != "https://staging.example.com" {
+		t.Errorf("unexpected environment %+v", notify.Environment)
+	}
+
+	if envs := wf.Jobs.Environments(); !slices.Equal(envs, []string{"production", "staging"}) {
+		t.Errorf("unexpected environments %v", envs)
+	}
+
+	if !wf.Concurrency.CancelsInProgress() || wf.Concurrency.Group != "deploy-${{ github.ref }}" {
+		t.Errorf("unexpected workflow concurrency %+v", wf.Concurrency)
+	}
+
+	if notify.Concurrency == nil || notify.Concurrency.Group != "notify" || notify.Concurrency.CancelsInProgress() {
+		t.Errorf("unexpected job concurrency %+v", notify.Concurrency)
+	}
+
+	if got := wf.Permissions.String(); got != "contents: read, deployments: write" {
+		t.Errorf("unexpected permissions %q", got)
+	}
+
+	if got := notify.Permissions.String(); got != "read-all" {
+		t.Errorf("unexpected job permissions %q", got)
+	}
+}
+
+func TestConcurrency_CancelInProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		c          *workflow.Concurrency
+		wantCancel bool
+		wantMay    bool
+	}{
+		{name: "nil", c: nil},
+		{name: "unset", c: &workflow.Concurrency{Group: "g"}},
+		{name: "true", c: &workflow.Concurrency{Group: "g", CancelInProgress: "true"}, wantCancel: true},
+		{name: "false", c: &workflow.Concurrency{Group: "g", CancelInProgress: "false"}},
+		{
+			name:    "expression",
+			c:       &workflow.Concurrency{Group: "g", CancelInProgress: "${{ github.ref != 'refs/heads/main' }}"},
+			wantMay: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.c.CancelsInProgress(); got != tt.wantCancel {
+				t.Errorf("CancelsInProgress() = %v, want %v", got, tt.wantCancel)
+			}
+
+			if got := tt.c.MayCancelInProgress(); got != tt.wantMay {
+				t.Errorf("MayCancelInProgress() = %v, want %v", got, tt.wantMay)
+			}
+		})
+	}
+}
+
+func TestPermissions_StringEmptyMapping(t *testing.T) {
+	t.Parallel()
+
+	wf, err := workflow.Parse([]byte("on: workflow_dispatch\npermissions: {}\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if got := wf.Permissions.String(); got != "none" {
+		t.Errorf("expected %q, got %q", "none", got)
+	}
+}
+
+func TestFile_InputNames(t *testing.T) {
+	t.Parallel()
+
+	wf := workflow.File{On: workflow.OnTrigger{Dispatch: &workflow.Dispatch{Inputs: map[string]workflow.Input{
+		"zeta":    {},
+		"alpha":   {},
+		"version": {Order: 2},
+		"env":     {Order: 1},
+		"hidden":  {Hidden: true},
+	}}}}
+
+	want := []string{"env", "version", "alpha", "hidden", "zeta"}
+	if got := wf.InputNames(); !slices.Equal(got, want) {
+		t.Errorf("InputNames() = %v, want %v", got, want)
+	}
+}
+
+func TestParse_OptionsComments(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`
+on:
+  workflow_dispatch:
+    inputs:
+      tag:
+        # lazydispatch:options:git-tags:v*
+        # lazydispatch:validate:prefix:v
+        type: string
+      environment:
+        # lazydispatch:options:cmd:./scripts/list-envs.sh --all
+        type: string
+      release:
+        # lazydispatch:options:releases
+        description: Release to deploy
+      branch:
+        # lazydispatch:options:svn-branches
+        type: string
+      twice:
+        # lazydispatch:options:releases
+        # lazydispatch:options:git-tags
+        type: string
+      pattern:
+        # lazydispatch:options:git-branches:release/[
+        type: string
+`)
+
+	wf, err := workflow.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	inputs := wf.GetInputs()
+
+	for name, want := range map[string]string{
+		"tag":         "git-tags:v*",
+		"environment": "cmd:./scripts/list-envs.sh --all",
+		"release":     "releases",
+	} {
+		if got := inputs[name].OptionsProvider; got == nil || got.String() != want {
+			t.Errorf("%s: expected provider %q, got %v", name, want, got)
+		}
+	}
+
+	if len(inputs["tag"].ValidationRules) != 1 {
+		t.Error("expected validation comments alongside the options comment to still apply")
+	}
+
+	wantErrs := []error{workflow.ErrUnknownOptionsProvider, workflow.ErrMultipleOptionsProviders, path.ErrBadPattern}
+	if len(wf.RuleErrors) != len(wantErrs) {
+		t.Fatalf("expected %d rule errors, got %v", len(wantErrs), wf.RuleErrors)
+	}
+
+	for _, want := range wantErrs {
+		if !slices.ContainsFunc(wf.RuleErrors, func(e workflow.ParseError) bool { return errors.Is(e.Err, want) }) {
+			t.Errorf("expected a rule error wrapping %v, got %v", want, wf.RuleErrors)
+		}
+	}
+}
+
+func TestOptionsProvider_Describe(t *testing.T) {
+	t.Parallel()
+
+	tests := map[workflow.OptionsProvider]string{
+		{Kind: workflow.OptionsGitTags, Arg: "v*"}:                "git tags matching v*",
+		{Kind: workflow.OptionsGitBranches}:                       "branches",
+		{Kind: workflow.OptionsReleases}:                          "releases",
+		{Kind: workflow.OptionsCommand, Arg: "./scripts/envs.sh"}: "output of ./scripts/envs.sh",
+	}
+
+	for provider, want := range tests {
+		if got := provider.Describe(); got != want {
+			t.Errorf("Describe(%v) = %q, want %q", provider, got, want)
+		}
+	}
+}
+
+func TestIsNumber(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]bool{
+		"3": true, "-1.5": true, "1e3": true,
+		"": false, "NaN": false, "Inf": false, "-inf": false, "0x10": false, "1_000": false, "three": false,
+	} {
+		if got := workflow.IsNumber(value); got != want {
+			t.Errorf("IsNumber(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
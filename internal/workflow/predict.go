@@ -0,0 +1,119 @@
+package workflow
+
+import "github.com/kyleking/gh-lazydispatch/internal/expr"
+
+// JobOutcome is the predicted result of a job's "if:" condition.
+type JobOutcome int
+
+// Job outcomes.
+const (
+	JobRuns JobOutcome = iota
+	JobSkipped
+	JobUnknown
+)
+
+// String returns the outcome as shown in the config pane.
+func (o JobOutcome) String() string {
+	switch o {
+	case JobRuns:
+		return "runs"
+	case JobSkipped:
+		return "skipped"
+	case JobUnknown:
+		return "unknown"
+	}
+
+	return ""
+}
+
+// JobPrediction pairs a job with its predicted outcome.
+type JobPrediction struct {
+	Job     Job
+	Outcome JobOutcome
+}
+
+// Predict evaluates each job's condition against ctx, in declaration order.
+// Jobs that need a skipped job are skipped too, unless their condition checks
+// status with always(), failure() or cancelled(). Conditions that cannot be
+// evaluated, and jobs that need one, are unknown.
+func (j Jobs) Predict(ctx expr.Context) []JobPrediction {
+	byID := make(map[string]Job, len(j))
+	for _, job := range j {
+		byID[job.ID] = job
+	}
+
+	outcomes := make(map[string]JobOutcome, len(j))
+	visiting := make(map[string]bool)
+
+	var predict func(id string) JobOutcome
+
+	predict = func(id string) JobOutcome {
+		if outcome, ok := outcomes[id]; ok {
+			return outcome
+		}
+
+		job, ok := byID[id]
+		if !ok || visiting[id] {
+			return JobUnknown
+		}
+
+		visiting[id] = true
+
+		// A skipped need outweighs an unknown one.
+		needs := JobRuns
+
+		for _, need := range job.Needs {
+			switch predict(need) {
+			case JobSkipped:
+				needs = JobSkipped
+			case JobUnknown:
+				if needs == JobRuns {
+					needs = JobUnknown
+				}
+			case JobRuns:
+			}
+		}
+
+		outcomes[id] = predictJob(job, needs, ctx)
+		visiting[id] = false
+
+		return outcomes[id]
+	}
+
+	predictions := make([]JobPrediction, len(j))
+	for i, job := range j {
+		predictions[i] = JobPrediction{Job: job, Outcome: predict(job.ID)}
+	}
+
+	return predictions
+}
+
+// predictJob combines a job's own condition with the worst outcome of the
+// jobs it needs.
+func predictJob(job Job, needs JobOutcome, ctx expr.Context) JobOutcome {
+	if job.If == "" {
+		return needs
+	}
+
+	condition, err := expr.Parse(job.If)
+	if err != nil {
+		return JobUnknown
+	}
+
+	if !condition.ChecksStatus() && needs == JobSkipped {
+		return JobSkipped
+	}
+
+	value, err := condition.Evaluate(ctx)
+
+	switch {
+	case err != nil:
+		return JobUnknown
+	case !value.Truthy():
+		return JobSkipped
+	case !condition.ChecksStatus() && needs == JobUnknown:
+		return JobUnknown
+	}
+
+	return JobRuns
+}
@@ -0,0 +1,92 @@
+package workflow_test
+
+import (
+	"testing"
+
+	"github.com/kyleking/gh-lazydispatch/internal/expr"
+	"github.com/kyleking/gh-lazydispatch/internal/workflow"
+)
+
+func TestJobs_Predict(t *testing.T) {
+	t.Parallel()
+
+	jobs := workflow.Jobs{
+		{ID: "build"},
+		{ID: "deploy", Needs: workflow.StringList{"build"}, If: "${{ inputs.deploy }}"},
+		{ID: "smoke", Needs: workflow.StringList{"deploy"}},
+		{ID: "notify", Needs: workflow.StringList{"deploy"}, If: "always()"},
+		{ID: "publish", If: "github.ref_name == 'main' && vars.PUBLISH == 'true'"},
+		{ID: "docs", Needs: workflow.StringList{"publish"}},
+		{ID: "cleanup", Needs: workflow.StringList{"smoke", "publish"}},
+		{ID: "orphan", Needs: workflow.StringList{"missing"}},
+		{ID: "broken", If: "inputs.deploy =="},
+	}
+
+	tests := []struct {
+		name   string
+		inputs map[string]expr.Value
+		want   map[string]workflow.JobOutcome
+	}{
+		{
+			name:   "deploy off",
+			inputs: map[string]expr.Value{"deploy": expr.Bool(false)},
+			want: map[string]workflow.JobOutcome{
+				"build":   workflow.JobRuns,
+				"deploy":  workflow.JobSkipped,
+				"smoke":   workflow.JobSkipped,
+				"notify":  workflow.JobRuns,
+				"publish": workflow.JobUnknown,
+				"docs":    workflow.JobUnknown,
+				"cleanup": workflow.JobSkipped,
+				"orphan":  workflow.JobUnknown,
+				"broken":  workflow.JobUnknown,
+			},
+		},
+		{
+			name:   "deploy on",
+			inputs: map[string]expr.Value{"deploy": expr.Bool(true)},
+			want: map[string]workflow.JobOutcome{
+				"deploy":  workflow.JobRuns,
+				"smoke":   workflow.JobRuns,
+				"cleanup": workflow.JobUnknown,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := expr.Context{
+				Inputs: tt.inputs,
+				Github: map[string]expr.Value{"ref_name": expr.String("main")},
+			}
+
+			predictions := jobs.Predict(ctx)
+			if len(predictions) != len(jobs) {
+				t.Fatalf("expected %d predictions, got %d", len(jobs), len(predictions))
+			}
+
+			for _, p := range predictions {
+				if want, ok := tt.want[p.Job.ID]; ok && p.Outcome != want {
+					t.Errorf("job %s: got %s, want %s", p.Job.ID, p.Outcome, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJobs_PredictCycleIsUnknown(t *testing.T) {
+	t.Parallel()
+
+	jobs := workflow.Jobs{
+		{ID: "a", Needs: workflow.StringList{"b"}},
+		{ID: "b", Needs: workflow.StringList{"a"}},
+	}
+
+	for _, p := range jobs.Predict(expr.Context{}) {
+		if p.Outcome != workflow.JobUnknown {
+			t.Errorf("job %s: got %s, want unknown", p.Job.ID, p.Outcome)
+		}
+	}
+}
@@ -1,18 +1,34 @@
 package workflow
 
-import "github.com/kyleking/gh-lazydispatch/internal/rule"
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/kyleking/gh-lazydispatch/internal/rule"
+)
 
 // File represents a parsed GitHub Actions workflow file.
 type File struct {
-	On       OnTrigger `yaml:"on"`
-	Name     string    `yaml:"name"`
-	Filename string    `yaml:"-"`
+	On          OnTrigger    `yaml:"on"`
+	Concurrency *Concurrency `yaml:"concurrency"`
+	Permissions *Permissions `yaml:"permissions"`
+	Name        string       `yaml:"name"`
+	Filename    string       `yaml:"-"`
+	Jobs        Jobs         `yaml:"jobs"`
+	RuleErrors  []ParseError `yaml:"-"`
+	// Calls is the tree of reusable workflows the jobs call, resolved at
+	// discovery against the repository's other workflow files.
+	Calls []ReusableCall `json:"-" yaml:"-"`
 }
 
 // OnTrigger represents the "on" field which can trigger workflows.
 type OnTrigger struct {
 	//nolint:tagliatelle // matches GitHub Actions workflow YAML schema
 	Dispatch *Dispatch `yaml:"workflow_dispatch"`
+	// Call declares the inputs of a reusable workflow, in the same shape as Dispatch.
+	//nolint:tagliatelle // matches GitHub Actions workflow YAML schema
+	Call *Dispatch `yaml:"workflow_call"`
 }
 
 // Dispatch represents the workflow_dispatch trigger configuration.
@@ -27,23 +43,68 @@ type Input struct {
 	Type            string                `yaml:"type"`
 	Options         []string              `yaml:"options"`
 	ValidationRules []rule.ValidationRule `yaml:"-"`
-	Required        bool                  `yaml:"required"`
+	// OptionsProvider lists the values of a free-text input at edit time.
+	OptionsProvider *OptionsProvider `yaml:"-"`
+	// Label, Order, Hidden and Locked come from lazydispatch.yml overrides.
+	Label    string `yaml:"-"`
+	Line     int    `yaml:"-"`
+	Column   int    `yaml:"-"`
+	Order    int    `yaml:"-"`
+	Required bool   `yaml:"required"`
+	Hidden   bool   `yaml:"-"`
+	Locked   bool   `yaml:"-"`
+}
+
+// DisplayName returns the input's label, falling back to its name.
+func (i Input) DisplayName(name string) string {
+	if i.Label != "" {
+		return i.Label
+	}
+
+	return name
+}
+
+// Input types supported by workflow_dispatch.
+const (
+	InputTypeString      = "string"
+	InputTypeBoolean     = "boolean"
+	InputTypeChoice      = "choice"
+	InputTypeNumber      = "number"
+	InputTypeEnvironment = "environment"
+)
+
+// KnownInputTypes lists every input type GitHub accepts for workflow_dispatch.
+var KnownInputTypes = []string{
+	InputTypeString, InputTypeBoolean, InputTypeChoice, InputTypeNumber, InputTypeEnvironment,
 }
 
 // InputType returns the normalized input type, defaulting to "string".
 func (i Input) InputType() string {
 	if i.Type == "" {
-		return "string"
+		return InputTypeString
 	}
 
 	return i.Type
 }
 
+// IsNumber reports whether value is a number a number input accepts: a
+// decimal that is also a JSON number, as GitHub receives it. This rules out
+// NaN, Inf and hexadecimal, which strconv.ParseFloat alone accepts.
+func IsNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil && json.Valid([]byte(value))
+}
+
 // IsDispatchable returns true if the workflow has workflow_dispatch trigger.
 func (w File) IsDispatchable() bool {
 	return w.On.Dispatch != nil
 }
 
+// IsReusable returns true if the workflow has a workflow_call trigger.
+func (w File) IsReusable() bool {
+	return w.On.Call != nil
+}
+
 // GetInputs returns the workflow inputs, or empty map if none.
 func (w File) GetInputs() map[string]Input {
 	if w.On.Dispatch == nil || w.On.Dispatch.Inputs == nil {
@@ -52,3 +113,29 @@ func (w File) GetInputs() map[string]Input {
 
 	return w.On.Dispatch.Inputs
 }
+
+// InputNames returns the names of the workflow's inputs in display order:
+// inputs with an Order first, ascending, then the rest alphabetically.
+func (w File) InputNames() []string {
+	inputs := w.GetInputs()
+
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		a, b := inputs[names[i]].Order, inputs[names[j]].Order
+
+		switch {
+		case a == b:
+			return names[i] < names[j]
+		case a == 0 || b == 0:
+			return b == 0
+		}
+
+		return a < b
+	})
+
+	return names
+}